@@ -1,27 +1,26 @@
 package vueglue
 
 import (
-	"embed"
 	"io/fs"
-	"log"
 	"net/http"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
-//go:embed react
-var embedFiles embed.FS
-
 // FileServer is a customized version of http.FileServer
 // that can handle either an embed.FS or a os.DirFS fs.FS.
 // Since development directories used for hot updates
 // can contain dot files (potentially with sensitive
 // information) the code checks to make sure that dot files
 // are not served.
+//
+// Requests are not logged; wrap the handler with LogRequests
+// if you want that.
 func (vg *VueGlue) FileServer() (http.Handler, error) {
 	// First, make sure if our fs.FS is from an embed.FS,
 	// that we adjust where the FS is "pointing".
-	target, err := correctEmbedFS(vg.DistFS, vg.JSProjectPath)
+	target, err := correctEmbedFS(vg.distFS, vg.jsProjectPath)
 	if err != nil {
 		return nil, err
 	}
@@ -63,52 +62,34 @@ func (vg *VueGlue) guardedFileServer(serveDir fs.FS) http.Handler {
 			}
 		}
 
-		// handle any special-cased files
-		if len(parts) > 0 {
-			baseFile := parts[len(parts)-1]
-			if baseFile == "preamble.js" {
-				// react preamble file
-				bytes, err := embedFiles.ReadFile("react/preamble.js")
-				if err != nil {
-					log.Println("could not load preamble:", err)
-					http.NotFound(w, r)
-					return
-				}
-				serveOneFile(w, r, bytes, "application/javascript")
-				return
-			}
-		}
-
-		if vg.Debug {
-			log.Println("entered FS", r.URL.Path)
+		if vg.debug {
+			logger := vg.log()
+			logger.Debug("entered FS", "path", r.URL.Path)
 			dir, err := fs.ReadDir(serveDir, ".")
 			if err != nil {
-				log.Println("could not read the asset dir", err)
+				logger.Error("could not read the asset dir", "err", err)
 				http.NotFound(w, r)
 				return
 			}
 
 			for _, item := range dir {
-				log.Println(item.Name())
+				logger.Debug("asset dir entry", "name", item.Name())
 			}
 
 		}
-		var loggingFS http.Handler
 		var fileServer http.Handler
-		if vg.Environment == "production" {
+		if vg.environment == "production" {
 			// We actually want to read from the dist subdir of
 			// the JSDir.
-			newDir, err := fs.Sub(serveDir, vg.AssetPath)
+			newDir, err := fs.Sub(serveDir, vg.assetPath)
 			if err != nil {
 				w.WriteHeader(http.StatusNotFound)
 				return
 			}
-			loggingFS = logRequest(http.FileServer(http.FS(newDir)))
-			fileServer = loggingFS
+			fileServer = http.FileServer(http.FS(newDir))
 
 		} else {
-			loggingFS = logRequest(http.FileServer(http.FS(serveDir)))
-			fileServer = http.StripPrefix(stripPrefix, loggingFS)
+			fileServer = http.StripPrefix(stripPrefix, http.FileServer(http.FS(serveDir)))
 		}
 
 		fileServer.ServeHTTP(w, r)
@@ -152,15 +133,6 @@ func (wrpr wrapperFS) Open(path string) (fs.File, error) {
 	return f, nil
 }
 
-// serveOneFile is used for serving special-cased files.
-func serveOneFile(w http.ResponseWriter, r *http.Request, data []byte, ctype string) {
-	w.Header().Add("Content-Type", ctype)
-	_, err := w.Write(data)
-	if err != nil {
-		log.Println("could not write file:", err)
-	}
-}
-
 // Logger writes out status codes:
 
 type WriterWrapper struct {
@@ -188,16 +160,25 @@ func (w *WriterWrapper) Write(buf []byte) (int, error) {
 	return w.Writer.Write(buf)
 }
 
-func logRequest(next http.Handler) http.Handler {
+// LogRequests is middleware that logs each request passing
+// through next to the glue's Logger, along with the status
+// code and how long the request took.
+//
+//	mux.Handle("/src/", glue.LogRequests(fsHandler))
+func (vg *VueGlue) LogRequests(next http.Handler) http.Handler {
+	logger := vg.log()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
 		ww := NewRespWriter(w)
 		next.ServeHTTP(ww, r)
-		defer func() {
-			log.Printf(
-				"%s - %s %s %s (%d)",
-				r.RemoteAddr, r.Proto, r.Method,
-				r.URL.RequestURI(), ww.RetCode,
-			)
-		}()
+		logger.Info(
+			"request",
+			"method", r.Method,
+			"path", r.URL.RequestURI(),
+			"status", ww.RetCode,
+			"duration", time.Since(start),
+			"environment", vg.environment,
+			"remote", r.RemoteAddr,
+		)
 	})
 }
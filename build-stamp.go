@@ -0,0 +1,184 @@
+package vueglue
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// StampFile is the name of the build stamp, which lives next to
+// the manifest.
+const StampFile = "vite-go-stamp.json"
+
+// BuildStamp records what a build was built from.
+type BuildStamp struct {
+	// SourceHash is the SHA-256 of the JS project's sources.
+	SourceHash string `json:"sourceHash"`
+
+	// Files is how many source files went into the hash.
+	Files int `json:"files"`
+}
+
+// HashSources hashes the sources of the JS project fsys is rooted
+// at: every file, by path and contents, apart from the build in
+// assetsPath, node_modules, and anything whose name starts with a
+// dot (.git, .vite and the like) or an underscore. Those are the
+// files a go:embed directive leaves out unless it has the all:
+// prefix, so the hash is the same whether the project is read from
+// disk or embedded either way.
+func HashSources(fsys fs.FS, assetsPath string) (*BuildStamp, error) {
+	if assetsPath == "" {
+		assetsPath = "dist"
+	}
+	stamp := &BuildStamp{}
+	sum := sha256.New()
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") || strings.HasPrefix(entry.Name(), "_") ||
+			entry.Name() == "node_modules" || name == path.Clean(assetsPath) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		file, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		// WalkDir goes in lexical order, so the hash is stable
+		fmt.Fprintf(sum, "%s\x00", name)
+		contents := sha256.New()
+		if _, err := io.Copy(contents, file); err != nil {
+			return err
+		}
+		sum.Write(contents.Sum(nil))
+		stamp.Files++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	stamp.SourceHash = hex.EncodeToString(sum.Sum(nil))
+	return stamp, nil
+}
+
+// WriteBuildStamp hashes the sources of the JS project in dir,
+// and records the hash next to the manifest of the build in
+// assetsPath (relative to dir). Run it after each `vite build`,
+// for instance from go generate:
+//
+//	//go:generate sh -c "cd frontend && npm run build"
+//	//go:generate go run github.com/torenware/vite-go/cmd/vitestamp -dir frontend
+func WriteBuildStamp(dir, assetsPath string) error {
+	fsys := os.DirFS(dir)
+	config := ViteConfig{AssetsPath: assetsPath}
+	if err := config.SetProductionDefaults(); err != nil {
+		return err
+	}
+	manifest, _, err := config.findManifest(fsys)
+	if err != nil {
+		return err
+	}
+	stamp, err := HashSources(fsys, config.AssetsPath)
+	if err != nil {
+		return err
+	}
+	contents, err := json.MarshalIndent(stamp, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(dir, filepath.FromSlash(path.Join(path.Dir(manifest), StampFile)))
+	return os.WriteFile(name, append(contents, '\n'), 0644)
+}
+
+// CheckBuildStamp compares the sources of the JS project fsys is
+// rooted at against the stamp WriteBuildStamp left with the build
+// in assetsPath. It returns an error wrapping ErrStaleBuild if
+// they differ, and ErrNoBuildStamp if the build was never
+// stamped. It suits a test that keeps a stale build out of a
+// release:
+//
+//	func TestFrontendBuilt(t *testing.T) {
+//		if err := vueglue.CheckBuildStamp(os.DirFS("frontend"), "dist"); err != nil {
+//			t.Fatal(err)
+//		}
+//	}
+func CheckBuildStamp(fsys fs.FS, assetsPath string) error {
+	config := ViteConfig{AssetsPath: assetsPath}
+	if err := config.SetProductionDefaults(); err != nil {
+		return err
+	}
+	manifest, _, err := config.findManifest(fsys)
+	if err != nil {
+		return err
+	}
+	return checkStamp(fsys, manifest, config.AssetsPath)
+}
+
+// checkStamp does the work for CheckBuildStamp, given where the
+// manifest is.
+func checkStamp(fsys fs.FS, manifest, assetsPath string) error {
+	contents, err := fs.ReadFile(fsys, path.Join(path.Dir(manifest), StampFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNoBuildStamp
+	}
+	if err != nil {
+		return err
+	}
+	var built BuildStamp
+	if err := json.Unmarshal(contents, &built); err != nil {
+		return err
+	}
+
+	current, err := HashSources(fsys, assetsPath)
+	if err != nil {
+		return err
+	}
+	if current.SourceHash != built.SourceHash {
+		return fmt.Errorf(
+			"%w: built from %d file(s), sources now have %d; run vite build again",
+			ErrStaleBuild,
+			built.Files,
+			current.Files,
+		)
+	}
+	return nil
+}
+
+// warnIfStale logs a warning at startup when the FS has both the
+// build and the sources it came from, and they do not match.
+func (vg *VueGlue) warnIfStale() {
+	if vg.config.SkipVerify || vg.distFS == nil || vg.manifestFile == "" {
+		return
+	}
+	if _, err := fs.Stat(vg.distFS, "package.json"); err != nil {
+		// only the build was deployed
+		return
+	}
+	err := checkStamp(vg.distFS, vg.manifestFile, vg.assetPath)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrNoBuildStamp):
+		vg.log().Debug("build has no stamp; not checking it against the sources")
+	default:
+		vg.log().Warn("build may be out of date", "error", err)
+	}
+}
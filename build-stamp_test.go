@@ -0,0 +1,106 @@
+package vueglue
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeProject lays out a JS project with a Vite 5 style build.
+func writeProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json":                 `{"devDependencies": {"vite": "^5.0.0"}}`,
+		"index.html":                   `<script type="module" src="/src/main.ts"></script>`,
+		"src/main.ts":                  `console.log("hello")`,
+		"node_modules/vite/index.js":   `export {}`,
+		".vite/deps/cache.json":        `{}`,
+		"dist/.vite/manifest.json":     `{"src/main.ts": {"file": "assets/main-B1a2c3d4.js", "src": "src/main.ts", "isEntry": true}}`,
+		"dist/assets/main-B1a2c3d4.js": `console.log("hello")`,
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestBuildStamp(t *testing.T) {
+	dir := writeProject(t)
+	if err := CheckBuildStamp(os.DirFS(dir), "dist"); !errors.Is(err, ErrNoBuildStamp) {
+		t.Errorf("expected ErrNoBuildStamp, got %v", err)
+	}
+
+	if err := WriteBuildStamp(dir, "dist"); err != nil {
+		t.Fatalf("could not stamp build: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "dist", ".vite", StampFile)); err != nil {
+		t.Errorf("stamp not next to the manifest: %s", err)
+	}
+	if err := CheckBuildStamp(os.DirFS(dir), "dist"); err != nil {
+		t.Errorf("fresh build reported stale: %s", err)
+	}
+
+	// neither dependencies nor caches are sources, and files
+	// go:embed would leave out are not hashed either
+	if err := os.WriteFile(filepath.Join(dir, "src", "_partial.ts"), []byte("export {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"node_modules/vite/index.js", ".vite/deps/cache.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("changed"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := CheckBuildStamp(os.DirFS(dir), "dist"); err != nil {
+		t.Errorf("build reported stale after changes outside the sources: %s", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "src", "main.ts"), []byte(`console.log("bye")`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckBuildStamp(os.DirFS(dir), "dist"); !errors.Is(err, ErrStaleBuild) {
+		t.Errorf("expected ErrStaleBuild, got %v", err)
+	}
+}
+
+func TestStaleBuildWarning(t *testing.T) {
+	dir := writeProject(t)
+	if err := WriteBuildStamp(dir, "dist"); err != nil {
+		t.Fatalf("could not stamp build: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "extra.ts"), []byte(`export {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+	glue, err := NewVueGlue(&ViteConfig{
+		Environment: "production",
+		FS:          os.DirFS(dir),
+		Logger:      slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if err != nil {
+		t.Fatalf("stale build refused: %s", err)
+	}
+	if !strings.Contains(logs.String(), "build may be out of date") {
+		t.Errorf("no warning logged: %s", logs.String())
+	}
+
+	// The sources are only hashed at startup.
+	logs.Reset()
+	if err := glue.Reload(); err != nil {
+		t.Fatalf("reload failed: %s", err)
+	}
+	if strings.Contains(logs.String(), "build may be out of date") {
+		t.Errorf("reload checked the sources again: %s", logs.String())
+	}
+}
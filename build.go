@@ -0,0 +1,267 @@
+package vueglue
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BuildOptions configures Build. The zero value builds the JS
+// project in frontend the way `vite build --manifest` would.
+type BuildOptions struct {
+	// Dir is the JS project. Default is frontend.
+	Dir string
+
+	// Mode is the Vite mode (--mode). Vite's default is
+	// production.
+	Mode string
+
+	// OutDir is the build directory relative to Dir (--outDir),
+	// or an absolute path. Default is dist.
+	OutDir string
+
+	// Base is the public base path (--base).
+	Base string
+
+	// Manifest is the name of the manifest, relative to OutDir.
+	// A manifest is always written, since Build returns it; if
+	// this is empty, Vite picks where.
+	Manifest string
+
+	// Sourcemap is "true", "inline" or "hidden" to write source
+	// maps (--sourcemap). Empty leaves it to the Vite config.
+	Sourcemap string
+
+	// PackageManager (npm, pnpm, yarn or bun) runs Vite. Default
+	// is whichever one's lockfile is in Dir, or else npm.
+	PackageManager string
+
+	// Command overrides the command that runs Vite; the build
+	// arguments are added after it.
+	Command []string
+
+	// Env is added to the build's environment.
+	Env []string
+
+	// Args are passed on to vite build after the others.
+	Args []string
+}
+
+// BuildResult is the outcome of a successful Build.
+type BuildResult struct {
+	// Manifest is the chunk graph of the new build, and
+	// ManifestPath where it was written, relative to Dir (or
+	// absolute, if OutDir is an absolute path outside Dir).
+	Manifest     Manifest
+	ManifestPath string
+
+	// Command is what was run.
+	Command []string
+
+	// Stdout and Stderr are the build's output.
+	Stdout string
+	Stderr string
+
+	// Duration is how long the build took.
+	Duration time.Duration
+}
+
+// BuildError is returned by Build when Vite fails. It matches
+// ErrBuildFailed with errors.Is.
+type BuildError struct {
+	// Command is what was run.
+	Command []string
+
+	// ExitCode is the exit status, or -1 if the command did
+	// not run to completion.
+	ExitCode int
+
+	// Stdout and Stderr are the build's output.
+	Stdout string
+	Stderr string
+
+	// Err is the underlying error from running the command.
+	Err error
+}
+
+func (e *BuildError) Error() string {
+	msg := fmt.Sprintf("%s (%s, exit status %d)", ErrBuildFailed, strings.Join(e.Command, " "), e.ExitCode)
+	if output := strings.TrimSpace(e.Stderr); output != "" {
+		msg += ": " + output
+	}
+	return msg
+}
+
+func (e *BuildError) Unwrap() []error {
+	return []error{ErrBuildFailed, e.Err}
+}
+
+// lockfiles tells which package manager a project uses.
+var lockfiles = []struct {
+	file, manager string
+}{
+	{"pnpm-lock.yaml", "pnpm"},
+	{"yarn.lock", "yarn"},
+	{"bun.lock", "bun"},
+	{"bun.lockb", "bun"},
+	{"package-lock.json", "npm"},
+}
+
+// viteCommands run the project's own copy of Vite.
+var viteCommands = map[string][]string{
+	"npm":  {"npm", "exec", "--", "vite"},
+	"pnpm": {"pnpm", "exec", "vite"},
+	"yarn": {"yarn", "vite"},
+	"bun":  {"bun", "x", "vite"},
+}
+
+// detectPackageManager looks for a lockfile in the project.
+func detectPackageManager(fsys fs.FS) string {
+	for _, lock := range lockfiles {
+		if _, err := fs.Stat(fsys, lock.file); err == nil {
+			return lock.manager
+		}
+	}
+	return "npm"
+}
+
+// args turns the options into vite build arguments.
+func (opts *BuildOptions) args() []string {
+	args := []string{"build", "--outDir", opts.OutDir}
+	if opts.Manifest != "" {
+		args = append(args, "--manifest="+opts.Manifest)
+	} else {
+		args = append(args, "--manifest")
+	}
+	if opts.Mode != "" {
+		args = append(args, "--mode", opts.Mode)
+	}
+	if opts.Base != "" {
+		args = append(args, "--base", opts.Base)
+	}
+	switch opts.Sourcemap {
+	case "":
+	case "true":
+		args = append(args, "--sourcemap")
+	default:
+		args = append(args, "--sourcemap="+opts.Sourcemap)
+	}
+	return append(args, opts.Args...)
+}
+
+// Build runs `vite build` in the JS project through its package
+// manager, and returns the manifest of the new build. It suits
+// go generate and integration tests:
+//
+//	result, err := vueglue.Build(ctx, vueglue.BuildOptions{Mode: "staging"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(result.Manifest.Keys())
+//
+// If Vite fails, the error is a *BuildError with its output.
+func Build(ctx context.Context, opts BuildOptions) (*BuildResult, error) {
+	if opts.Dir == "" {
+		opts.Dir = "frontend"
+	}
+	if opts.OutDir == "" {
+		opts.OutDir = "dist"
+	}
+	fsys := os.DirFS(opts.Dir)
+
+	command := opts.Command
+	if len(command) == 0 {
+		manager := opts.PackageManager
+		if manager == "" {
+			manager = detectPackageManager(fsys)
+		}
+		var ok bool
+		command, ok = viteCommands[manager]
+		if !ok {
+			return nil, fmt.Errorf("unknown package manager %q", manager)
+		}
+	}
+	command = append(append([]string(nil), command...), opts.args()...)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Dir = opts.Dir
+	// keep color codes out of the captured output
+	cmd.Env = append(append(os.Environ(), "NO_COLOR=1"), opts.Env...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	if err := cmd.Run(); err != nil {
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		return nil, &BuildError{
+			Command:  command,
+			ExitCode: exitCode,
+			Stdout:   stdout.String(),
+			Stderr:   stderr.String(),
+			Err:      err,
+		}
+	}
+	result := &BuildResult{
+		Command:  command,
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		Duration: time.Since(start),
+	}
+
+	// An fs.FS only takes relative paths, so an absolute OutDir
+	// is read relative to Dir, or else from its own parent.
+	outDir, outFS, outRoot := opts.OutDir, fsys, ""
+	if filepath.IsAbs(opts.OutDir) {
+		var err error
+		outDir, outFS, outRoot, err = absOutDir(opts.Dir, opts.OutDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	config := ViteConfig{AssetsPath: outDir}
+	if opts.Manifest != "" {
+		config.ManifestPath = path.Join(outDir, opts.Manifest)
+	}
+	manifestFile, contents, err := config.findManifest(outFS)
+	if err != nil {
+		return nil, err
+	}
+	if outRoot != "" {
+		manifestFile = filepath.Join(outRoot, filepath.FromSlash(manifestFile))
+	}
+	var target manifestTarget
+	result.Manifest, err = target.parseChunks(contents)
+	if err != nil {
+		return nil, err
+	}
+	result.ManifestPath = manifestFile
+	return result, nil
+}
+
+// absOutDir finds an absolute OutDir for reading: as a path in
+// Dir's FS if it is inside Dir, or else as a directory in an FS
+// for its parent, which is returned as root.
+func absOutDir(dir, outDir string) (name string, fsys fs.FS, root string, err error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if rel, err := filepath.Rel(absDir, outDir); err == nil && rel != "." && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel), os.DirFS(dir), "", nil
+	}
+	root = filepath.Dir(outDir)
+	return filepath.Base(outDir), os.DirFS(root), root, nil
+}
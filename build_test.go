@@ -0,0 +1,140 @@
+package vueglue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// TestViteBuildHelper is not a real test: it stands in for
+// `vite build` in the tests below. It writes a Vite 5 manifest
+// to the --outDir it is given.
+func TestViteBuildHelper(t *testing.T) {
+	if os.Getenv("GO_WANT_VITE_BUILD") != "1" {
+		t.Skip("helper process for the Build tests")
+	}
+	args := os.Args
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	fmt.Println(strings.Join(args, " "))
+	if os.Getenv("VITE_BUILD_FAIL") == "1" {
+		fmt.Fprintln(os.Stderr, "error during build:\nCould not resolve entry module \"src/main.ts\".")
+		os.Exit(1)
+	}
+
+	outDir := "dist"
+	for i, arg := range args {
+		if arg == "--outDir" {
+			outDir = args[i+1]
+		}
+	}
+	manifest := `{"src/main.ts": {"file": "assets/main-B1a2c3d4.js", "name": "main", "src": "src/main.ts", "isEntry": true}}`
+	if err := os.MkdirAll(filepath.Join(outDir, ".vite"), 0755); err != nil {
+		os.Exit(2)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, ".vite", "manifest.json"), []byte(manifest), 0644); err != nil {
+		os.Exit(2)
+	}
+	os.Exit(0)
+}
+
+func helperBuild(dir string, env ...string) BuildOptions {
+	return BuildOptions{
+		Dir:     dir,
+		Command: []string{os.Args[0], "-test.run=^TestViteBuildHelper$", "--"},
+		Env:     append([]string{"GO_WANT_VITE_BUILD=1"}, env...),
+	}
+}
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+	opts := helperBuild(dir)
+	opts.Mode = "staging"
+	opts.OutDir = "public/build"
+	opts.Base = "/static/"
+	opts.Sourcemap = "hidden"
+
+	result, err := Build(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("build failed: %s", err)
+	}
+	if result.ManifestPath != "public/build/.vite/manifest.json" {
+		t.Errorf("manifest read from %s", result.ManifestPath)
+	}
+	if chunk := result.Manifest["src/main.ts"]; chunk == nil || chunk.File != "assets/main-B1a2c3d4.js" {
+		t.Errorf("manifest not parsed: %+v", result.Manifest)
+	}
+	want := "build --outDir public/build --manifest --mode staging --base /static/ --sourcemap=hidden"
+	if strings.TrimSpace(result.Stdout) != want {
+		t.Errorf("vite got %q, want %q", strings.TrimSpace(result.Stdout), want)
+	}
+}
+
+func TestBuildAbsoluteOutDir(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	tests := []struct {
+		outDir, manifest string
+	}{
+		{filepath.Join(dir, "public", "build"), "public/build/.vite/manifest.json"},
+		{filepath.Join(outside, "build"), filepath.Join(outside, "build", ".vite", "manifest.json")},
+	}
+	for _, test := range tests {
+		opts := helperBuild(dir)
+		opts.OutDir = test.outDir
+		result, err := Build(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("%s: build failed: %s", test.outDir, err)
+		}
+		if result.ManifestPath != test.manifest {
+			t.Errorf("%s: manifest read from %s, want %s", test.outDir, result.ManifestPath, test.manifest)
+		}
+		if chunk := result.Manifest["src/main.ts"]; chunk == nil {
+			t.Errorf("%s: manifest not parsed: %+v", test.outDir, result.Manifest)
+		}
+	}
+}
+
+func TestBuildFailure(t *testing.T) {
+	_, err := Build(context.Background(), helperBuild(t.TempDir(), "VITE_BUILD_FAIL=1"))
+	var buildErr *BuildError
+	if !errors.As(err, &buildErr) || !errors.Is(err, ErrBuildFailed) {
+		t.Fatalf("expected a BuildError, got %v", err)
+	}
+	if buildErr.ExitCode != 1 {
+		t.Errorf("exit code %d", buildErr.ExitCode)
+	}
+	if !strings.Contains(buildErr.Stderr, "Could not resolve entry module") {
+		t.Errorf("stderr not captured: %q", buildErr.Stderr)
+	}
+}
+
+func TestBuildCommand(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"npm":  {"package.json": {}},
+		"pnpm": {"package.json": {}, "pnpm-lock.yaml": {}},
+		"yarn": {"package.json": {}, "yarn.lock": {}},
+		"bun":  {"package.json": {}, "bun.lockb": {}},
+	}
+	for manager, fsys := range tests {
+		if got := detectPackageManager(fsys); got != manager {
+			t.Errorf("detected %s, want %s", got, manager)
+		}
+	}
+
+	opts := BuildOptions{OutDir: "dist", Manifest: "assets.json", Sourcemap: "true", Args: []string{"--emptyOutDir"}}
+	want := []string{"build", "--outDir", "dist", "--manifest=assets.json", "--sourcemap", "--emptyOutDir"}
+	if got := opts.args(); !reflect.DeepEqual(got, want) {
+		t.Errorf("args %v, want %v", got, want)
+	}
+}
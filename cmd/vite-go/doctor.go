@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"time"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+var errProblems = errors.New("found problems")
+
+// checkup collects the results of the doctor's checks.
+type checkup struct {
+	out      io.Writer
+	problems int
+}
+
+func (c *checkup) ok(format string, args ...interface{}) {
+	fmt.Fprintf(c.out, "ok    "+format+"\n", args...)
+}
+
+func (c *checkup) warn(format string, args ...interface{}) {
+	fmt.Fprintf(c.out, "warn  "+format+"\n", args...)
+}
+
+func (c *checkup) fail(format string, args ...interface{}) {
+	c.problems++
+	fmt.Fprintf(c.out, "FAIL  "+format+"\n", args...)
+}
+
+// runDoctor checks a project: its package.json and entry points,
+// whether the dev server is up, and whether the build in dist
+// matches its manifest and its sources.
+func runDoctor(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	dir := flags.String("dir", "frontend", "the JS project")
+	assets := flags.String("assets", "dist", "the build directory, relative to -dir")
+	server := flags.String("server", "", "the dev server's URL (default: worked out from the project)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	fsys := os.DirFS(*dir)
+	c := &checkup{out: stdout}
+
+	// Development
+	glue, err := vueglue.NewVueGlue(&vueglue.ViteConfig{
+		Environment:   "development",
+		FS:            fsys,
+		JSProjectPath: *dir,
+	})
+	if err != nil {
+		c.fail("package.json: %s", err)
+	} else {
+		config := glue.Config()
+		c.ok("package.json: %s project, Vite %s", config.Platform, config.ViteVersion)
+
+		entries := config.DevDefaults.EntryPoints
+		if len(entries) == 0 {
+			entries = []string{config.EntryPoint}
+		}
+		for _, entry := range entries {
+			if _, err := fs.Stat(fsys, entry); err != nil {
+				c.fail("entry point %s (from %s) does not exist", entry, config.DevDefaults.EntrySource)
+			} else {
+				c.ok("entry point %s (from %s)", entry, config.DevDefaults.EntrySource)
+			}
+		}
+
+		if *server == "" {
+			*server = glue.BaseURL()
+		}
+		checkDevServer(c, *server)
+	}
+
+	// Production
+	prod, err := vueglue.NewVueGlue(&vueglue.ViteConfig{
+		Environment:   "production",
+		FS:            fsys,
+		JSProjectPath: *dir,
+		AssetsPath:    *assets,
+		SkipVerify:    true,
+	})
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		c.warn("no build in %s; run vite build to check one", *assets)
+	case err != nil:
+		c.fail("manifest: %s", err)
+	default:
+		c.ok("manifest: %s, main module %s", prod.ManifestPath(), prod.MainModule())
+		checkBuild(c, prod, fsys, *assets)
+	}
+
+	if c.problems > 0 {
+		return fmt.Errorf("%w: %d", errProblems, c.problems)
+	}
+	return nil
+}
+
+// checkDevServer sees if a Vite dev server answers at url.
+func checkDevServer(c *checkup, url string) {
+	client := http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Get(url + "/@vite/client")
+	if err != nil {
+		c.warn("dev server not reachable at %s; is vite running?", url)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		c.fail("dev server at %s answered %s for /@vite/client; is it a Vite server?", url, resp.Status)
+		return
+	}
+	c.ok("dev server running at %s", url)
+}
+
+// checkBuild compares the build against its manifest, and its
+// stamp against the sources.
+func checkBuild(c *checkup, glue *vueglue.VueGlue, fsys fs.FS, assets string) {
+	var verr *vueglue.VerifyError
+	err := glue.Verify()
+	switch {
+	case err == nil:
+		c.ok("every file in the manifest is in %s", assets)
+	case errors.As(err, &verr):
+		for _, file := range verr.Missing {
+			c.fail("missing from the build: %s", file)
+		}
+		if len(verr.Missing) == 0 {
+			c.ok("every file in the manifest is in %s", assets)
+		}
+		for _, file := range verr.Orphans {
+			c.warn("not in the manifest: %s", file)
+		}
+	default:
+		c.fail("could not check the build: %s", err)
+	}
+
+	err = vueglue.CheckBuildStamp(fsys, assets)
+	switch {
+	case err == nil:
+		c.ok("build matches its sources")
+	case errors.Is(err, vueglue.ErrNoBuildStamp):
+		c.warn("build has no stamp, so may not match its sources; see cmd/vitestamp")
+	default:
+		c.fail("%s", err)
+	}
+}
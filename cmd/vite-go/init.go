@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed all:scaffold
+var scaffold embed.FS
+
+// scaffoldPlugin is how a platform's Vite plugin is set up.
+type scaffoldPlugin struct {
+	Import string
+	Call   string
+}
+
+// scaffoldPlatform describes what init writes for a platform.
+type scaffoldPlatform struct {
+	Plugin          scaffoldPlugin
+	JSX             bool
+	Dependencies    map[string]string
+	DevDependencies map[string]string
+	TSDependencies  map[string]string
+}
+
+const viteVersion = "^6.0.0"
+
+var scaffoldPlatforms = map[string]scaffoldPlatform{
+	"vue": {
+		Plugin:          scaffoldPlugin{"import vue from '@vitejs/plugin-vue'", "[vue()]"},
+		Dependencies:    map[string]string{"vue": "^3.5.0"},
+		DevDependencies: map[string]string{"@vitejs/plugin-vue": "^5.2.0"},
+		TSDependencies:  map[string]string{"vue-tsc": "^2.1.0"},
+	},
+	"react": {
+		Plugin:          scaffoldPlugin{"import react from '@vitejs/plugin-react'", "[react()]"},
+		JSX:             true,
+		Dependencies:    map[string]string{"react": "^18.3.1", "react-dom": "^18.3.1"},
+		DevDependencies: map[string]string{"@vitejs/plugin-react": "^4.3.0"},
+		TSDependencies:  map[string]string{"@types/react": "^18.3.0", "@types/react-dom": "^18.3.0"},
+	},
+	"preact": {
+		Plugin:          scaffoldPlugin{"import preact from '@preact/preset-vite'", "[preact()]"},
+		JSX:             true,
+		Dependencies:    map[string]string{"preact": "^10.24.0"},
+		DevDependencies: map[string]string{"@preact/preset-vite": "^2.9.0"},
+	},
+	"svelte": {
+		Plugin:          scaffoldPlugin{"import { svelte } from '@sveltejs/vite-plugin-svelte'", "[svelte()]"},
+		DevDependencies: map[string]string{"svelte": "^5.0.0", "@sveltejs/vite-plugin-svelte": "^5.0.0"},
+		TSDependencies:  map[string]string{"svelte-check": "^4.0.0"},
+	},
+	"vanilla": {},
+}
+
+// scaffoldData is what the templates are executed against.
+type scaffoldData struct {
+	Name     string
+	Module   string
+	Platform string
+	Plugin   scaffoldPlugin
+	TS       bool
+	Ext      string
+	JSXExt   string
+	Entry    string
+}
+
+// packageJSON is the package.json init writes.
+type packageJSON struct {
+	Name            string            `json:"name"`
+	Private         bool              `json:"private"`
+	Version         string            `json:"version"`
+	Type            string            `json:"type"`
+	Scripts         map[string]string `json:"scripts"`
+	Dependencies    map[string]string `json:"dependencies,omitempty"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+// runInit writes a new Go + Vite project from the embedded
+// templates.
+func runInit(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("init", flag.ContinueOnError)
+	platform := flags.String("platform", "vue", "vue, react, preact, svelte or vanilla")
+	ts := flags.Bool("ts", false, "use TypeScript")
+	dir := flags.String("dir", ".", "where to create the project")
+	module := flags.String("module", "", "module path for go.mod, if there is none (default: the directory's name)")
+	force := flags.Bool("force", false, "overwrite existing files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	spec, ok := scaffoldPlatforms[*platform]
+	if !ok {
+		names := make([]string, 0, len(scaffoldPlatforms))
+		for name := range scaffoldPlatforms {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown platform %q; pick one of %s", *platform, strings.Join(names, ", "))
+	}
+
+	abs, err := filepath.Abs(*dir)
+	if err != nil {
+		return err
+	}
+	data := scaffoldData{
+		Name:     filepath.Base(abs),
+		Module:   *module,
+		Platform: *platform,
+		Plugin:   spec.Plugin,
+		TS:       *ts,
+		Ext:      "js",
+		JSXExt:   "jsx",
+	}
+	if data.Module == "" {
+		data.Module = data.Name
+	}
+	if *ts {
+		data.Ext, data.JSXExt = "ts", "tsx"
+	}
+	data.Entry = "src/main." + data.Ext
+	if spec.JSX {
+		data.Entry = "src/main." + data.JSXExt
+	}
+
+	files, err := scaffoldFiles(data)
+	if err != nil {
+		return err
+	}
+	files["frontend/package.json"], err = spec.packageJSON(data)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(*dir, "go.mod")); err == nil {
+		delete(files, "go.mod")
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if !*force {
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(*dir, name)); err == nil {
+				return fmt.Errorf("%s already exists; use -force to overwrite", name)
+			}
+		}
+	}
+	for _, name := range names {
+		target := filepath.Join(*dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, files[name], 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "created %s\n", name)
+	}
+
+	fmt.Fprintln(stdout)
+	fmt.Fprintln(stdout, "Next:")
+	fmt.Fprintln(stdout, "  go mod tidy")
+	fmt.Fprintln(stdout, "  (cd frontend && npm install && npm run dev) &")
+	fmt.Fprintln(stdout, "  go run .")
+	fmt.Fprintln(stdout, "and for production:")
+	fmt.Fprintln(stdout, "  go generate && go build && ./"+data.Name+" -env production")
+	return nil
+}
+
+// scaffoldFiles renders the templates for a project, keyed by
+// where they go.
+func scaffoldFiles(data scaffoldData) (map[string][]byte, error) {
+	groups := map[string]string{
+		"go":          ".",
+		"frontend":    "frontend",
+		data.Platform: "frontend",
+	}
+	if data.TS {
+		groups["frontend-ts"] = "frontend"
+	}
+
+	files := map[string][]byte{}
+	for group, target := range groups {
+		root := path.Join("scaffold", group)
+		err := fs.WalkDir(scaffold, root, func(name string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			contents, err := fs.ReadFile(scaffold, name)
+			if err != nil {
+				return err
+			}
+			tmpl, err := template.New(name).Delims("[[", "]]").Parse(string(contents))
+			if err != nil {
+				return err
+			}
+			var out bytes.Buffer
+			if err := tmpl.Execute(&out, data); err != nil {
+				return err
+			}
+			files[path.Join(target, scaffoldName(strings.TrimPrefix(name, root+"/"), data))] = out.Bytes()
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// scaffoldName turns a template's name into the file's: the
+// .tmpl goes, dot- becomes a dot, and EXT and JSX become the
+// project's extensions.
+func scaffoldName(name string, data scaffoldData) string {
+	name = strings.TrimSuffix(name, ".tmpl")
+	dir, base := path.Split(name)
+	if strings.HasPrefix(base, "dot-") {
+		base = "." + strings.TrimPrefix(base, "dot-")
+	}
+	base = strings.Replace(base, ".EXT", "."+data.Ext, 1)
+	base = strings.Replace(base, ".JSX", "."+data.JSXExt, 1)
+	return dir + base
+}
+
+// packageJSON writes the frontend's package.json.
+func (spec scaffoldPlatform) packageJSON(data scaffoldData) ([]byte, error) {
+	pkg := packageJSON{
+		Name:    data.Name,
+		Private: true,
+		Version: "0.0.0",
+		Type:    "module",
+		Scripts: map[string]string{
+			"dev":     "vite",
+			"build":   "vite build",
+			"preview": "vite preview",
+		},
+		Dependencies:    spec.Dependencies,
+		DevDependencies: map[string]string{"vite": viteVersion},
+	}
+	for name, version := range spec.DevDependencies {
+		pkg.DevDependencies[name] = version
+	}
+	if data.TS {
+		pkg.DevDependencies["typescript"] = "~5.6.0"
+		for name, version := range spec.TSDependencies {
+			pkg.DevDependencies[name] = version
+		}
+	}
+	contents, err := json.MarshalIndent(pkg, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(contents, '\n'), nil
+}
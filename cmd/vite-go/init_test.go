@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		platform string
+		ts       bool
+		entry    string
+		files    []string
+	}{
+		{"vue", false, "src/main.js", []string{"src/App.vue", "vite.config.js"}},
+		{"vue", true, "src/main.ts", []string{"src/App.vue", "vite.config.ts", "tsconfig.json"}},
+		{"react", false, "src/main.jsx", []string{"src/App.jsx"}},
+		{"react", true, "src/main.tsx", []string{"src/App.tsx", "src/vite-env.d.ts"}},
+		{"preact", true, "src/main.tsx", []string{"src/App.tsx"}},
+		{"svelte", false, "src/main.js", []string{"src/App.svelte", "svelte.config.js"}},
+		{"svelte", true, "src/main.ts", []string{"src/App.svelte", "tsconfig.json"}},
+		{"vanilla", false, "src/main.js", []string{"src/style.css"}},
+		{"vanilla", true, "src/main.ts", []string{"tsconfig.json"}},
+	}
+
+	for _, test := range tests {
+		name := test.platform
+		if test.ts {
+			name += "-ts"
+		}
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "app")
+			args := []string{"init", "-platform", test.platform, "-dir", dir}
+			if test.ts {
+				args = append(args, "-ts")
+			}
+			var stdout, stderr bytes.Buffer
+			if status := run(args, &stdout, &stderr); status != 0 {
+				t.Fatalf("exit status %d: %s", status, stderr.String())
+			}
+
+			frontend := filepath.Join(dir, "frontend")
+			for _, file := range append(test.files, test.entry, "package.json", "dist/.gitkeep") {
+				if _, err := os.Stat(filepath.Join(frontend, file)); err != nil {
+					t.Errorf("%s was not created", file)
+				}
+			}
+
+			var pkg map[string]interface{}
+			contents, _ := os.ReadFile(filepath.Join(frontend, "package.json"))
+			if err := json.Unmarshal(contents, &pkg); err != nil {
+				t.Errorf("package.json is not JSON: %s", err)
+			}
+
+			// the library must understand the project it made
+			config := vueglue.ViteConfig{FS: os.DirFS(frontend), JSProjectPath: frontend}
+			if err := config.SetDevelopmentDefaults(); err != nil {
+				t.Fatalf("project not understood: %s", err)
+			}
+			if config.Platform != test.platform || config.EntryPoint != test.entry {
+				t.Errorf("detected %s with entry %s", config.Platform, config.EntryPoint)
+			}
+			if config.DevServerPort != "5173" {
+				t.Errorf("dev server port %s", config.DevServerPort)
+			}
+
+			ext := "js"
+			if test.ts {
+				ext = "ts"
+			}
+			viteConfig, _ := os.ReadFile(filepath.Join(frontend, "vite.config."+ext))
+			for _, want := range []string{"manifest: true", "origin: 'http://localhost:5173'", "input: '" + test.entry + "'"} {
+				if !strings.Contains(string(viteConfig), want) {
+					t.Errorf("vite.config does not contain %q:\n%s", want, viteConfig)
+				}
+			}
+
+			mainGo, _ := os.ReadFile(filepath.Join(dir, "main.go"))
+			if _, err := parser.ParseFile(token.NewFileSet(), "main.go", mainGo, 0); err != nil {
+				t.Errorf("main.go does not parse: %s", err)
+			}
+			if !strings.Contains(string(mainGo), "//go:embed all:frontend/dist") {
+				t.Error("main.go does not embed the build")
+			}
+			goMod, _ := os.ReadFile(filepath.Join(dir, "go.mod"))
+			if !strings.HasPrefix(string(goMod), "module app\n") {
+				t.Errorf("go.mod: %s", goMod)
+			}
+		})
+	}
+}
+
+func TestInitExisting(t *testing.T) {
+	dir := t.TempDir()
+	goMod := []byte("module example.com/mine\n")
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), goMod, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"init", "-dir", dir}, &stdout, &stderr); status != 0 {
+		t.Fatalf("exit status %d: %s", status, stderr.String())
+	}
+	if contents, _ := os.ReadFile(filepath.Join(dir, "go.mod")); !bytes.Equal(contents, goMod) {
+		t.Errorf("go.mod was replaced:\n%s", contents)
+	}
+
+	stderr.Reset()
+	if status := run([]string{"init", "-dir", dir}, &stdout, &stderr); status != 1 {
+		t.Errorf("exit status %d", status)
+	}
+	if !strings.Contains(stderr.String(), "already exists") {
+		t.Errorf("existing files not refused: %s", stderr.String())
+	}
+
+	if status := run([]string{"init", "-platform", "angular", "-dir", dir}, &stdout, &stderr); status != 1 {
+		t.Errorf("unknown platform accepted")
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+// runInspect prints the configuration NewVueGlue resolves for a
+// project, and what it learned from the project's files.
+func runInspect(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	dir := flags.String("dir", "frontend", "the JS project")
+	env := flags.String("env", "development", "development or production")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	config := &vueglue.ViteConfig{
+		Environment:   *env,
+		FS:            os.DirFS(*dir),
+		JSProjectPath: *dir,
+	}
+	var err error
+	if *env == "production" {
+		err = config.SetProductionDefaults()
+	} else {
+		err = config.SetDevelopmentDefaults()
+	}
+	if err != nil {
+		return err
+	}
+
+	// DevDefaults is left out of ViteConfig's JSON
+	out := struct {
+		Config      *vueglue.ViteConfig  `json:"config"`
+		DevDefaults *vueglue.JSAppParams `json:"dev_defaults,omitempty"`
+	}{config, config.DevDefaults}
+	config.FS = nil
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
@@ -0,0 +1,72 @@
+// Command vite-go helps set up and debug Go programs that use
+// vite-go.
+//
+//	vite-go inspect  [-dir frontend] [-env development]
+//	vite-go manifest [-dir frontend] [-assets dist] [-manifest path]
+//	vite-go doctor   [-dir frontend] [-assets dist] [-server url]
+//	vite-go init     [-platform vue] [-ts] [-dir .] [-module path]
+//
+// inspect prints the configuration the library works out for a
+// JS project, manifest prints a build's chunk graph and the tags
+// for each entry, and doctor checks a project for common
+// problems. init creates a new project: a Vite frontend for the
+// chosen platform, and a Go program that serves it.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// command is one of the tool's subcommands.
+type command struct {
+	summary string
+	run     func(args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"inspect":  {"print the resolved configuration of a JS project", runInspect},
+	"manifest": {"print a build's chunk graph and tags", runManifest},
+	"doctor":   {"check a project for common problems", runDoctor},
+	"init":     {"create a new Go + Vite project", runInit},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the tool, and returns its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "vite-go: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	if err := cmd.run(args[1:], stdout); err != nil {
+		fmt.Fprintf(stderr, "vite-go %s: %s\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: vite-go <command> [flags]")
+	fmt.Fprintln(w)
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-9s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run vite-go <command> -h for the command's flags.")
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeProject lays out a small Vue project with a build.
+func writeProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json":                  `{"dependencies": {"vue": "^3.4.0"}, "devDependencies": {"vite": "^5.2.0", "typescript": "^5.4.0"}}`,
+		"src/main.ts":                   `import { createApp } from "vue"`,
+		"dist/.vite/manifest.json":      `{"src/main.ts": {"file": "assets/main-B1a2c3d4.js", "src": "src/main.ts", "isEntry": true, "css": ["assets/main-C5d6e7f8.css"]}}`,
+		"dist/assets/main-B1a2c3d4.js":  `console.log("hello")`,
+		"dist/assets/main-C5d6e7f8.css": `body {}`,
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestInspect(t *testing.T) {
+	dir := writeProject(t)
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"inspect", "-dir", dir}, &stdout, &stderr); status != 0 {
+		t.Fatalf("exit status %d: %s", status, stderr.String())
+	}
+	var out struct {
+		Config struct {
+			Platform      string
+			EntryPoint    string
+			DevServerPort string
+		} `json:"config"`
+		DevDefaults struct {
+			ViteVersion string `json:"vite_version"`
+		} `json:"dev_defaults"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("output is not JSON: %s\n%s", err, stdout.String())
+	}
+	if out.Config.Platform != "vue" || out.Config.EntryPoint != "src/main.ts" || out.Config.DevServerPort != "5173" {
+		t.Errorf("unexpected config: %+v", out.Config)
+	}
+	if out.DevDefaults.ViteVersion != "5.2.0" {
+		t.Errorf("unexpected defaults: %+v", out.DevDefaults)
+	}
+}
+
+func TestManifestCommand(t *testing.T) {
+	dir := writeProject(t)
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"manifest", "-dir", dir}, &stdout, &stderr); status != 0 {
+		t.Fatalf("exit status %d: %s", status, stderr.String())
+	}
+	for _, want := range []string{
+		"manifest: dist/.vite/manifest.json",
+		"src/main.ts (entry)",
+		"css: assets/main-C5d6e7f8.css",
+		`<script type="module" crossorigin src="/assets/main-B1a2c3d4.js"></script>`,
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output did not contain %q:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestDoctor(t *testing.T) {
+	vite := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/@vite/client" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer vite.Close()
+
+	dir := writeProject(t)
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"doctor", "-dir", dir, "-server", vite.URL}, &stdout, &stderr); status != 0 {
+		t.Fatalf("exit status %d: %s\n%s", status, stderr.String(), stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "ok    dev server running at "+vite.URL) {
+		t.Errorf("dev server not found:\n%s", stdout.String())
+	}
+
+	// a half-copied build
+	if err := os.Remove(filepath.Join(dir, "dist", "assets", "main-B1a2c3d4.js")); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	if status := run([]string{"doctor", "-dir", dir, "-server", vite.URL}, &stdout, &stderr); status != 1 {
+		t.Errorf("exit status %d", status)
+	}
+	if !strings.Contains(stdout.String(), "FAIL  missing from the build: assets/main-B1a2c3d4.js") {
+		t.Errorf("missing file not reported:\n%s", stdout.String())
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"frobnicate"}, &stdout, &stderr); status != 2 {
+		t.Errorf("exit status %d", status)
+	}
+	if !strings.Contains(stderr.String(), "usage: vite-go") {
+		t.Errorf("no usage:\n%s", stderr.String())
+	}
+}
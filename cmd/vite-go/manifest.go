@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+// runManifest prints each chunk of a build's manifest, then the
+// tags RenderTags would give each entry.
+func runManifest(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("manifest", flag.ContinueOnError)
+	dir := flags.String("dir", "frontend", "the JS project")
+	assets := flags.String("assets", "dist", "the build directory, relative to -dir")
+	path := flags.String("manifest", "", "the manifest, relative to -dir (default: found in -assets)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	manifest, name, err := vueglue.ReadManifest(os.DirFS(*dir), vueglue.ViteConfig{
+		AssetsPath:   *assets,
+		ManifestPath: *path,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "manifest: %s\n", name)
+
+	var entries []string
+	for _, key := range manifest.Keys() {
+		chunk := manifest[key]
+		var kinds []string
+		if chunk.IsEntry {
+			kinds = append(kinds, "entry")
+			if !manifest.IsLegacy(key) && !chunk.IsPolyfills() {
+				entries = append(entries, key)
+			}
+		}
+		if chunk.IsDynamicEntry {
+			kinds = append(kinds, "dynamic entry")
+		}
+		if manifest.IsLegacy(key) {
+			kinds = append(kinds, "legacy")
+		}
+		if chunk.IsPolyfills() {
+			kinds = append(kinds, "polyfills")
+		}
+		if chunk.IsStylesheet() {
+			kinds = append(kinds, "stylesheet")
+		}
+
+		fmt.Fprintf(stdout, "\n%s", key)
+		if len(kinds) > 0 {
+			fmt.Fprintf(stdout, " (%s)", strings.Join(kinds, ", "))
+		}
+		fmt.Fprintf(stdout, "\n  file: %s\n", chunk.File)
+		printList(stdout, "imports", chunk.Imports)
+		printList(stdout, "dynamic imports", chunk.DynamicImports)
+		printList(stdout, "css", chunk.CSS)
+		printList(stdout, "assets", chunk.Assets)
+	}
+
+	for _, key := range entries {
+		tags, err := manifest.Tags(key)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "\ntags for %s:\n", key)
+		for _, line := range strings.Split(string(tags), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(stdout, "  %s\n", line)
+			}
+		}
+	}
+	return nil
+}
+
+func printList(w io.Writer, label string, items []string) {
+	if len(items) > 0 {
+		fmt.Fprintf(w, "  %s: %s\n", label, strings.Join(items, ", "))
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+// generate writes the Go source for a manifest.
+func generate(pkg string, manifest vueglue.Manifest) ([]byte, error) {
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by vitegen; DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	src.WriteString("import (\n\t\"html/template\"\n\n\tvueglue \"github.com/torenware/vite-go\"\n)\n\n")
+
+	// Shared chunks are keyed by their hashed names, which change
+	// with every build, so only sourced chunks get constants.
+	names := identifiers(manifest)
+	keys := manifest.Keys()
+
+	src.WriteString("// Entry points, by their manifest keys.\nconst (\n")
+	for _, key := range keys {
+		if chunk := manifest[key]; chunk.IsEntry && names[key] != "" {
+			fmt.Fprintf(&src, "\tEntry%s = %q\n", names[key], key)
+		}
+	}
+	src.WriteString(")\n\n")
+
+	src.WriteString("// Built files, for each entry and asset.\nconst (\n")
+	for _, key := range keys {
+		if names[key] != "" {
+			fmt.Fprintf(&src, "\tFile%s = %q\n", names[key], manifest[key].File)
+		}
+	}
+	src.WriteString(")\n\n")
+
+	src.WriteString("// Tags are what RenderTags produces for each entry.\nconst (\n")
+	for _, key := range keys {
+		if chunk := manifest[key]; !chunk.IsEntry || names[key] == "" {
+			continue
+		}
+		tags, err := manifest.Tags(key)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&src, "\tTags%s template.HTML = %q\n", names[key], compact(string(tags)))
+	}
+	src.WriteString(")\n\n")
+
+	src.WriteString("// Files maps every manifest key to its built file.\nvar Files = map[string]string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&src, "\t%q: %q,\n", key, manifest[key].File)
+	}
+	src.WriteString("}\n\n")
+
+	src.WriteString("// ViteManifest is the manifest, for ViteConfig.Manifest.\nvar ViteManifest = vueglue.Manifest{\n")
+	for _, key := range keys {
+		chunk := manifest[key]
+		fmt.Fprintf(&src, "\t%q: {\n\t\tKey: %q,\n\t\tFile: %q,\n", key, key, chunk.File)
+		writeString(&src, "Name", chunk.Name)
+		writeString(&src, "Src", chunk.Src)
+		writeBool(&src, "IsEntry", chunk.IsEntry)
+		writeBool(&src, "IsDynamicEntry", chunk.IsDynamicEntry)
+		writeStrings(&src, "Imports", chunk.Imports)
+		writeStrings(&src, "DynamicImports", chunk.DynamicImports)
+		writeStrings(&src, "CSS", chunk.CSS)
+		writeStrings(&src, "Assets", chunk.Assets)
+		src.WriteString("\t},\n")
+	}
+	src.WriteString("}\n")
+
+	return format.Source(src.Bytes())
+}
+
+func writeString(src *bytes.Buffer, field, value string) {
+	if value != "" {
+		fmt.Fprintf(src, "\t\t%s: %q,\n", field, value)
+	}
+}
+
+func writeBool(src *bytes.Buffer, field string, value bool) {
+	if value {
+		fmt.Fprintf(src, "\t\t%s: true,\n", field)
+	}
+}
+
+func writeStrings(src *bytes.Buffer, field string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(src, "\t\t%s: []string{", field)
+	for i, value := range values {
+		if i > 0 {
+			src.WriteString(", ")
+		}
+		fmt.Fprintf(src, "%q", value)
+	}
+	src.WriteString("},\n")
+}
+
+// compact drops the template's indentation and blank lines.
+func compact(tags string) string {
+	var lines []string
+	for _, line := range strings.Split(tags, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// identifiers names each chunk that has a source file after its
+// key: src/main.ts becomes SrcMainTs. Clashes get a number.
+func identifiers(manifest vueglue.Manifest) map[string]string {
+	names := map[string]string{}
+	taken := map[string]bool{}
+	for _, key := range manifest.Keys() {
+		if manifest[key].Src == "" && strings.HasPrefix(key, "_") {
+			continue
+		}
+		name := identifier(key)
+		base := name
+		for n := 2; taken[name]; n++ {
+			name = fmt.Sprintf("%s%d", base, n)
+		}
+		taken[name] = true
+		names[key] = name
+	}
+	return names
+}
+
+// identifier turns a path into an exported Go name.
+func identifier(key string) string {
+	var name strings.Builder
+	upper := true
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		name.WriteRune(r)
+	}
+	if name.Len() == 0 || !unicode.IsLetter([]rune(name.String())[0]) {
+		return "X" + name.String()
+	}
+	return name.String()
+}
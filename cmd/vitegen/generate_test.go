@@ -0,0 +1,81 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+	"testing"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+func TestGenerate(t *testing.T) {
+	manifest, _, err := vueglue.ReadManifest(os.DirFS("../../testdata/builds/vite6"), vueglue.ViteConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := generate("assets", manifest)
+	if err != nil {
+		t.Fatalf("could not generate: %s", err)
+	}
+
+	// the output must compile
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "vite_assets.go", source, 0)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %s\n%s", err, source)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("assets", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatalf("generated code does not compile: %s\n%s", err, source)
+	}
+
+	constant := func(name string) string {
+		obj, ok := pkg.Scope().Lookup(name).(*types.Const)
+		if !ok {
+			t.Fatalf("no constant %s in:\n%s", name, source)
+		}
+		value := obj.Val().ExactString()
+		return strings.Trim(value, `"`)
+	}
+	if got := constant("EntrySrcMainTs"); got != "src/main.ts" {
+		t.Errorf("EntrySrcMainTs = %s", got)
+	}
+	if got := constant("FileSrcAssetsLogoPng"); got != "assets/logo-CbS1xR0p.png" {
+		t.Errorf("FileSrcAssetsLogoPng = %s", got)
+	}
+	if pkg.Scope().Lookup("EntrySrcViewsAboutVue") != nil {
+		t.Error("dynamic entry treated as an entry point")
+	}
+	for _, name := range []string{"TagsSrcMainTs", "TagsSrcAdminTs", "Files", "ViteManifest"} {
+		if pkg.Scope().Lookup(name) == nil {
+			t.Errorf("%s was not generated", name)
+		}
+	}
+	if strings.Contains(string(source), "Vendor") {
+		t.Error("hashed shared chunk got a constant")
+	}
+	if !strings.Contains(string(source), `src=\"/assets/main-C7wZp0Qe.js\"`) {
+		t.Errorf("tags not rendered:\n%s", source)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"src/main.ts":                        "SrcMainTs",
+		"src/islands/cart-widget.jsx":        "SrcIslandsCartWidgetJsx",
+		"index.html":                         "IndexHtml",
+		"../../vite/legacy-polyfills-legacy": "ViteLegacyPolyfillsLegacy",
+		"404.html":                           "X404Html",
+	}
+	for key, want := range tests {
+		if got := identifier(key); got != want {
+			t.Errorf("identifier(%q) = %s, want %s", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Command vitegen turns a Vite manifest into Go source: a
+// constant for every entry and asset, the manifest itself as a
+// vueglue.Manifest literal, and the production tags for each
+// entry, rendered ahead of time. A typo in an entry name then
+// fails to compile, and the glue has no JSON to parse at
+// startup. Run it from go generate after building the frontend:
+//
+//	//go:generate go run github.com/torenware/vite-go/cmd/vitegen -dir frontend -o vite_assets.go
+//
+// and hand the manifest to the glue:
+//
+//	glue, err := vueglue.NewVueGlue(&vueglue.ViteConfig{
+//		Environment: "production",
+//		FS:          dist,
+//		Manifest:    ViteManifest,
+//	})
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+func main() {
+	dir := flag.String("dir", "frontend", "the JS project")
+	assets := flag.String("assets", "dist", "the build directory, relative to -dir")
+	manifest := flag.String("manifest", "", "the manifest, relative to -dir (default: found in -assets)")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file (default: $GOPACKAGE, or main)")
+	out := flag.String("o", "vite_assets.go", "the file to write")
+	flag.Parse()
+
+	if *pkg == "" {
+		*pkg = "main"
+	}
+
+	chunks, _, err := vueglue.ReadManifest(os.DirFS(*dir), vueglue.ViteConfig{
+		AssetsPath:   *assets,
+		ManifestPath: *manifest,
+	})
+	if err == nil {
+		var source []byte
+		source, err = generate(*pkg, chunks)
+		if err == nil {
+			err = os.WriteFile(*out, source, 0644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vitegen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,35 @@
+// Command vitestamp records which sources a Vite build came from,
+// and checks a build against its sources later on. Run it from
+// go generate after building the frontend:
+//
+//	//go:generate sh -c "cd frontend && npm run build"
+//	//go:generate go run github.com/torenware/vite-go/cmd/vitestamp -dir frontend
+//
+// With -check, it exits with status 1 if the build is stale.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+func main() {
+	dir := flag.String("dir", "frontend", "the JS project")
+	assets := flag.String("assets", "dist", "the build directory, relative to -dir")
+	check := flag.Bool("check", false, "check the build instead of stamping it")
+	flag.Parse()
+
+	var err error
+	if *check {
+		err = vueglue.CheckBuildStamp(os.DirFS(*dir), *assets)
+	} else {
+		err = vueglue.WriteBuildStamp(*dir, *assets)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vitestamp:", err)
+		os.Exit(1)
+	}
+}
@@ -1,7 +1,6 @@
 package vueglue
 
 import (
-	"log"
 	"net/http"
 )
 
@@ -18,9 +17,9 @@ func (vg *VueGlue) DevServerRedirector() http.Handler {
 		}
 
 		rest := original[len(prefix)-1:]
-		log.Println("rest: ", rest)
+		vg.log().Debug("redirecting to dev server", "path", rest)
 		w.Header().Set("Content-Type", "application/javascript")
-		http.Redirect(w, r, vg.DevServer+rest, http.StatusPermanentRedirect)
+		http.Redirect(w, r, vg.DevServer()+rest, http.StatusPermanentRedirect)
 	}
 
 	return http.HandlerFunc(handler)
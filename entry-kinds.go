@@ -0,0 +1,44 @@
+package vueglue
+
+import (
+	"path"
+	"strings"
+)
+
+// stylesheetExts are the extensions of inputs Vite builds into
+// CSS rather than JS.
+var stylesheetExts = map[string]bool{
+	".css":     true,
+	".scss":    true,
+	".sass":    true,
+	".less":    true,
+	".styl":    true,
+	".stylus":  true,
+	".pcss":    true,
+	".postcss": true,
+}
+
+// isStylesheet reports whether an entry point or built file is
+// CSS (or something Vite compiles to CSS), judging by its
+// extension.
+func isStylesheet(name string) bool {
+	return stylesheetExts[strings.ToLower(path.Ext(name))]
+}
+
+// IsStylesheet reports whether the chunk is a CSS entry, such as
+// a .scss file listed in rollupOptions.input. Such entries are
+// linked with a stylesheet tag instead of a module script.
+func (c *Chunk) IsStylesheet() bool {
+	return isStylesheet(c.File) || isStylesheet(c.Key)
+}
+
+// firstScript returns the first entry that is not a stylesheet,
+// or the first entry if they all are.
+func firstScript(entries []string) string {
+	for _, entry := range entries {
+		if !isStylesheet(entry) {
+			return entry
+		}
+	}
+	return entries[0]
+}
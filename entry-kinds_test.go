@@ -0,0 +1,109 @@
+package vueglue
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIsStylesheet(t *testing.T) {
+	tests := map[string]bool{
+		"src/main.ts":          false,
+		"src/main.jsx":         false,
+		"src/print.css":        true,
+		"src/print.scss":       true,
+		"styles/theme.sass":    true,
+		"styles/theme.less":    true,
+		"styles/theme.styl":    true,
+		"styles/theme.stylus":  true,
+		"styles/theme.pcss":    true,
+		"styles/theme.postcss": true,
+		"styles/THEME.CSS":     true,
+		"assets/print.0f.css":  true,
+	}
+	for name, want := range tests {
+		if got := isStylesheet(name); got != want {
+			t.Errorf("isStylesheet(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	if got := firstScript([]string{"src/print.scss", "src/main.ts"}); got != "src/main.ts" {
+		t.Errorf("firstScript picked %q", got)
+	}
+	if got := firstScript([]string{"src/print.scss"}); got != "src/print.scss" {
+		t.Errorf("firstScript picked %q", got)
+	}
+}
+
+func TestStylesheetEntries(t *testing.T) {
+	contents, err := os.ReadFile("testdata/manifest-css.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	glue, err := ParseManifest(contents)
+	if err != nil {
+		t.Fatalf("manifest did not parse: %s", err)
+	}
+	if glue.MainModule() != "assets/main.4f1c2a9e.js" {
+		t.Errorf("stylesheet picked over JS entry: %s", glue.MainModule())
+	}
+
+	tmpl := template.Must(template.New("page").Funcs(glue.FuncMap()).Parse(
+		`{{ vite_entry "src/print.scss" }}{{ vite_entry "src/email.css" }}{{ vite_entry "src/print.scss" }}`,
+	))
+	var buf bytes.Buffer
+	if err := tmpl.Funcs(glue.Islands("").FuncMap()).Execute(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := `<link rel="stylesheet" href="/assets/print.0f9e8d7c.css">` +
+		`<link rel="stylesheet" href="/assets/email.3c2b1a09.css">`
+	if buf.String() != want {
+		t.Errorf("got %s, want %s", buf.String(), want)
+	}
+
+	// A build with nothing but stylesheets
+	cssOnly := []byte(`{"src/print.scss": {"file": "assets/print.0f9e8d7c.css", "src": "src/print.scss", "isEntry": true}}`)
+	glue, err = ParseManifest(cssOnly)
+	if err != nil {
+		t.Fatalf("manifest did not parse: %s", err)
+	}
+	tags, err := glue.RenderTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(tags), `<link rel="stylesheet" href="/assets/print.0f9e8d7c.css">`) ||
+		strings.Contains(string(tags), "<script") {
+		t.Errorf("stylesheet entry rendered as a script:\n%s", tags)
+	}
+}
+
+func TestStylesheetEntryDevelopment(t *testing.T) {
+	glue, err := initializeVueGlue(&ViteConfig{
+		Environment: "development",
+		FS:          os.DirFS("testdata"),
+		ViteVersion: "2",
+		Platform:    "vue",
+		EntryPoint:  "src/print.scss",
+	})
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+	tags, err := glue.RenderTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(tags), `<link rel="stylesheet" href="http://localhost:3000/src/print.scss">`) ||
+		strings.Contains(string(tags), "<script") {
+		t.Errorf("stylesheet entry rendered as a script:\n%s", tags)
+	}
+
+	entry, err := glue.Islands("").Entry("src/email.css")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry != `<link rel="stylesheet" href="http://localhost:3000/src/email.css">` {
+		t.Errorf("dev entry: %s", entry)
+	}
+}
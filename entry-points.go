@@ -0,0 +1,187 @@
+package vueglue
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Where a detected entry point came from.
+const (
+	EntrySourceViteConfig  = "vite.config"
+	EntrySourceIndexHTML   = "index.html"
+	EntrySourcePackageJSON = "package.json"
+)
+
+// viteConfigFiles are the names Vite looks for its config under.
+var viteConfigFiles = []string{
+	"vite.config.js",
+	"vite.config.mjs",
+	"vite.config.cjs",
+	"vite.config.ts",
+	"vite.config.mts",
+	"vite.config.cts",
+}
+
+var (
+	scriptTagRE = regexp.MustCompile(`(?is)<script\b([^>]*)>`)
+	attrRE      = regexp.MustCompile(`([\w-]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+	inputRE     = regexp.MustCompile(`(?s)rollupOptions\s*:\s*\{.*?\binput\s*:\s*`)
+	quotedRE    = regexp.MustCompile("(['\"`])([^'\"`]+)['\"`](\\s*:)?")
+)
+
+// readProjectFile reads a file from the JS project. Like
+// package.json, it is found relative to JSProjectPath when the
+// FS is an uncorrected embed.FS.
+func (vc *ViteConfig) readProjectFile(name string) ([]byte, error) {
+	prefix := ""
+	if _, ok := vc.FS.(embed.FS); ok {
+		prefix = vc.JSProjectPath + "/"
+	}
+	return fs.ReadFile(vc.FS, prefix+name)
+}
+
+// detectEntryPoints looks for the project's entry points the way
+// Vite itself finds them: first in build.rollupOptions.input in
+// the Vite config, then in the module scripts of index.html. It
+// returns the entries and where they came from, or nothing if
+// neither source had any.
+func (vc *ViteConfig) detectEntryPoints() ([]string, string) {
+	for _, name := range viteConfigFiles {
+		contents, err := vc.readProjectFile(name)
+		if err != nil {
+			continue
+		}
+		var entries []string
+		for _, input := range rollupInputs(contents) {
+			if path.Ext(input) != ".html" {
+				entries = append(entries, input)
+				continue
+			}
+			// HTML inputs get their entries from their scripts.
+			html, err := vc.readProjectFile(input)
+			if err != nil {
+				continue
+			}
+			for _, script := range moduleScripts(html) {
+				entries = append(entries, resolveScript(input, script))
+			}
+		}
+		if len(entries) > 0 {
+			return entries, EntrySourceViteConfig
+		}
+		break
+	}
+
+	html, err := vc.readProjectFile("index.html")
+	if err != nil {
+		return nil, ""
+	}
+	var entries []string
+	for _, script := range moduleScripts(html) {
+		entries = append(entries, resolveScript("index.html", script))
+	}
+	if len(entries) > 0 {
+		return entries, EntrySourceIndexHTML
+	}
+	return nil, ""
+}
+
+// moduleScripts returns the src of each local
+// <script type="module" src="..."> tag in an HTML document.
+func moduleScripts(html []byte) []string {
+	var scripts []string
+	for _, tag := range scriptTagRE.FindAllSubmatch(html, -1) {
+		attrs := map[string]string{}
+		for _, attr := range attrRE.FindAllSubmatch(tag[1], -1) {
+			value := string(attr[2]) + string(attr[3]) + string(attr[4])
+			attrs[strings.ToLower(string(attr[1]))] = value
+		}
+		src := attrs["src"]
+		if attrs["type"] != "module" || src == "" {
+			continue
+		}
+		// Skip anything not in the project: other hosts, and
+		// Vite's own modules such as /@vite/client.
+		if strings.Contains(src, "://") || strings.HasPrefix(src, "//") || strings.HasPrefix(src, "/@") {
+			continue
+		}
+		scripts = append(scripts, src)
+	}
+	return scripts
+}
+
+// resolveScript turns a script src found in htmlFile into a
+// path relative to the project root. Root relative paths
+// ("/src/main.ts") are relative to the project already.
+func resolveScript(htmlFile, src string) string {
+	if strings.HasPrefix(src, "/") {
+		return strings.TrimPrefix(src, "/")
+	}
+	return path.Join(path.Dir(htmlFile), src)
+}
+
+// rollupInputs pulls the paths out of build.rollupOptions.input
+// in a Vite config. The config is JavaScript, so this is a best
+// effort: it handles a string, an array or an object of paths,
+// including paths wrapped in resolve(__dirname, ...) calls.
+func rollupInputs(config []byte) []string {
+	loc := inputRE.FindIndex(config)
+	if loc == nil {
+		return nil
+	}
+	value, err := jsValue(config[loc[1]:])
+	if err != nil {
+		return nil
+	}
+
+	var inputs []string
+	for _, match := range quotedRE.FindAllSubmatch(value, -1) {
+		if len(match[3]) > 0 {
+			// an object key, not a path
+			continue
+		}
+		input := path.Clean(strings.TrimPrefix(string(match[2]), "/"))
+		if input == "." || input == "__dirname" {
+			continue
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs
+}
+
+var errNoJSValue = errors.New("could not find end of JS value")
+
+// jsValue returns the JS expression at the start of src: up to
+// the comma or closing bracket that ends it, skipping nested
+// brackets and strings.
+func jsValue(src []byte) ([]byte, error) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '{' || c == '[' || c == '(':
+			depth++
+		case c == '}' || c == ']' || c == ')':
+			if depth == 0 {
+				return src[:i], nil
+			}
+			depth--
+		case c == ',' && depth == 0:
+			return src[:i], nil
+		}
+	}
+	return nil, errNoJSValue
+}
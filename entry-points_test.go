@@ -0,0 +1,85 @@
+package vueglue
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDetectEntryPoints(t *testing.T) {
+	tstList := []struct {
+		project string
+		source  string
+		entries []string
+	}{
+		{"html-entry", EntrySourceIndexHTML, []string{"src/app/boot.tsx", "src/extra.ts"}},
+		{"rollup-input", EntrySourceViteConfig, []string{"src/shop/entry.js", "admin/admin.js"}},
+		{"rollup-string", EntrySourceViteConfig, []string{"src/entry-client.js"}},
+	}
+
+	for _, test := range tstList {
+		for name, config := range map[string]*ViteConfig{
+			"dirfs": {
+				FS: os.DirFS("testdata/projects/" + test.project),
+			},
+			"embed": {
+				FS:            embedTest,
+				JSProjectPath: "testdata/projects/" + test.project,
+			},
+		} {
+			if err := config.SetDevelopmentDefaults(); err != nil {
+				t.Fatalf("%s/%s: defaults failed: %s", test.project, name, err)
+			}
+			defaults := config.DevDefaults
+			if defaults.EntrySource != test.source {
+				t.Errorf("%s/%s: source expected %s, got %s", test.project, name, test.source, defaults.EntrySource)
+			}
+			if !reflect.DeepEqual(defaults.EntryPoints, test.entries) {
+				t.Errorf("%s/%s: entries expected %v, got %v", test.project, name, test.entries, defaults.EntryPoints)
+			}
+			if config.EntryPoint != test.entries[0] {
+				t.Errorf("%s/%s: entry point expected %s, got %s", test.project, name, test.entries[0], config.EntryPoint)
+			}
+		}
+	}
+}
+
+func TestEntryPointFallback(t *testing.T) {
+	// testdata/index.html has no scripts, so the platform
+	// default wins.
+	config := &ViteConfig{FS: os.DirFS("testdata")}
+	if err := config.SetDevelopmentDefaults(); err != nil {
+		t.Fatalf("defaults failed: %s", err)
+	}
+	if config.DevDefaults.EntrySource != EntrySourcePackageJSON || config.EntryPoint != "src/main.ts" {
+		t.Errorf("expected the vue-ts default, got %s from %s", config.EntryPoint, config.DevDefaults.EntrySource)
+	}
+
+	// An explicit entry point is left alone.
+	config = &ViteConfig{FS: os.DirFS("testdata/projects/html-entry"), EntryPoint: "src/other.tsx"}
+	if err := config.SetDevelopmentDefaults(); err != nil {
+		t.Fatalf("defaults failed: %s", err)
+	}
+	if config.EntryPoint != "src/other.tsx" || config.DevDefaults.EntryPoint != "src/app/boot.tsx" {
+		t.Errorf("explicit entry point was overridden: %s", config.EntryPoint)
+	}
+}
+
+func TestRollupInputs(t *testing.T) {
+	tstList := []struct {
+		config string
+		inputs []string
+	}{
+		{`build: { rollupOptions: { input: 'src/a.js' } }`, []string{"src/a.js"}},
+		{`rollupOptions: { external: ['vue'], input: ["src/a.js", 'b/index.html'], }`, []string{"src/a.js", "b/index.html"}},
+		{`rollupOptions: { input: { main: fileURLToPath(new URL('./index.html', import.meta.url)) } }`, []string{"index.html"}},
+		{`rollupOptions: { output: { format: 'iife' } }`, nil},
+		{`rollupOptions: { input: 'unterminated`, nil},
+	}
+	for _, test := range tstList {
+		inputs := rollupInputs([]byte(test.config))
+		if !reflect.DeepEqual(inputs, test.inputs) {
+			t.Errorf("%s: expected %v, got %v", test.config, test.inputs, inputs)
+		}
+	}
+}
@@ -6,7 +6,25 @@ import (
 
 var (
 	ErrNoEntryPoint        = errors.New("manifest lacked entry point")
+	ErrAmbiguousEntryPoint = errors.New("manifest has several entries; set EntryPoint to the main one")
 	ErrNoInputFile         = errors.New("expected import file name")
 	ErrManifestBadlyFormed = errors.New("manifest has unexpected format")
 	ErrManifestDNF         = errors.New("vue distribution directory not found")
+	ErrManifestNotFound    = errors.New("manifest.json not found")
+	ErrUnknownEntry        = errors.New("entry not found in manifest")
+	ErrNoIslandSet         = errors.New("vite_island and vite_entry need a per-request IslandSet; see VueGlue.Islands")
+	ErrPlatformName        = errors.New("platform needs a name")
+	ErrNoSSRRenderer       = errors.New("no SSR renderer configured")
+	ErrSidecarExited       = errors.New("SSR sidecar exited")
+	ErrStaleBuild          = errors.New("build does not match its sources")
+	ErrNoBuildStamp        = errors.New("build has no stamp")
+	ErrBuildFailed         = errors.New("vite build failed")
+
+	// ErrNotProduction is returned when a production-only
+	// operation is attempted on a development glue.
+	ErrNotProduction = errors.New("operation requires a production glue")
+
+	// ErrManifestCompiled is returned by Reload when the manifest
+	// was compiled in through ViteConfig.Manifest.
+	ErrManifestCompiled = errors.New("manifest is compiled in, so cannot be reloaded")
 )
@@ -53,10 +53,10 @@ func logRequest(next http.Handler) http.Handler {
 
 func serveOneFile(w http.ResponseWriter, r *http.Request, uri, contentType string) {
 	strippedURI := uri[1:]
-	buf, err := fs.ReadFile(vueData.DistFS, strippedURI)
+	buf, err := fs.ReadFile(vueData.DistFS(), strippedURI)
 	if err != nil {
 		// Try public dir
-		buf, err = fs.ReadFile(vueData.DistFS, "public/"+strippedURI)
+		buf, err = fs.ReadFile(vueData.DistFS(), "public/"+strippedURI)
 	}
 
 	// If we ended up nil, render the file out.
@@ -80,9 +80,9 @@ func pageWithAVue(w http.ResponseWriter, r *http.Request) {
 	re := regexp.MustCompile(`^/([^.]+)\.(svg|ico|jpg)$`)
 	matches := re.FindStringSubmatch(r.RequestURI)
 	if matches != nil {
-		if vueData.Environment == "development" {
+		if vueData.Environment() == "development" {
 			log.Printf("vite logo requested")
-			url := vueData.BaseURL + r.RequestURI
+			url := vueData.BaseURL() + r.RequestURI
 			http.Redirect(w, r, url, http.StatusPermanentRedirect)
 			return
 		} else {
@@ -177,11 +177,11 @@ func main() {
 		log.Println("could not set up static file server", err)
 		return
 	}
-	mux.Handle(config.URLPrefix, fsHandler)
+	mux.Handle(config.URLPrefix, glue.LogRequests(fsHandler))
 	mux.Handle("/", logRequest(http.HandlerFunc(pageWithAVue)))
 
 	log.Println("Starting server on :4000")
-	generatedConfig, _ := json.MarshalIndent(config, "", "  ")
+	generatedConfig, _ := json.MarshalIndent(glue.Config(), "", "  ")
 	log.Println("Generated Configuration:\n", string(generatedConfig))
 	err = http.ListenAndServe(":4000", mux)
 	log.Fatal(err)
@@ -0,0 +1,120 @@
+package vueglue
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// addSeeds adds every file matching the patterns to the corpus.
+func addSeeds(f *testing.F, patterns ...string) {
+	f.Helper()
+	for _, pattern := range patterns {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, file := range files {
+			contents, err := os.ReadFile(file)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(contents)
+		}
+	}
+}
+
+func FuzzParseManifest(f *testing.F) {
+	addSeeds(f,
+		"testdata/manifest*.json",
+		"testdata/builds/*/dist/manifest.json",
+		"testdata/builds/*/dist/.vite/manifest.json",
+	)
+	for _, seed := range []string{
+		``, `null`, `[]`, `"src/main.ts"`, `{"src/main.ts": null}`, `{"src/main.ts": []}`,
+		`{"src/main.ts": {"file": {}, "isEntry": true}}`,
+		`{"src/main.ts": {"file": "a.js", "isEntry": "yes"}}`,
+		`{"src/main.ts": {"file": "a.js", "isEntry": true, "imports": [{}, 1, null]}}`,
+		`{"src/main.ts": {"file": "a.js", "isEntry": true, "imports": "_b.js"}}`,
+		`{"src/main.ts": {"file": "a.js", "isEntry": true, "imports": ["src/main.ts"]}}`,
+		`{"src/main.ts": {"file": "a.js", "isEntry": true`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, contents []byte) {
+		glue, err := ParseManifest(contents)
+		if err != nil {
+			if !errors.Is(err, ErrManifestBadlyFormed) && !errors.Is(err, ErrNoEntryPoint) && !errors.Is(err, ErrAmbiguousEntryPoint) && !errors.Is(err, ErrNoInputFile) {
+				t.Fatalf("untyped error: %v", err)
+			}
+			return
+		}
+
+		snap := glue.Snapshot()
+		if snap.MainModule == "" {
+			t.Fatal("glue without a main module")
+		}
+		if _, err := glue.RenderTagsWithNonce("n"); err != nil {
+			t.Fatalf("tags did not render: %s", err)
+		}
+
+		// what parsed once parses the same way again
+		again, err := json.Marshal(snap.Manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reparsed, err := ParseManifest(again)
+		if err != nil {
+			t.Fatalf("re-encoded manifest did not parse: %s\n%s", err, again)
+		}
+		if reparsed.MainModule() != snap.MainModule || !reflect.DeepEqual(reparsed.Imports(), glue.Imports()) {
+			t.Fatalf("re-encoded manifest parsed differently:\n%s", again)
+		}
+	})
+}
+
+func FuzzAnalyzePackageJSON(f *testing.F) {
+	addSeeds(f, "testdata/pkg-json/*.json", "testdata/package.json", "testdata/projects/*/package.json")
+	for _, seed := range []string{
+		``, `null`, `{}`, `{"devDependencies": null}`,
+		`{"devDependencies": {"vite": "latest"}}`,
+		`{"devDependencies": {"vite": "^5.0.0-beta.1"}, "dependencies": {"react": "*"}}`,
+	} {
+		f.Add([]byte(seed))
+	}
+	digits := regexp.MustCompile(`^\d*$`)
+
+	f.Fuzz(func(t *testing.T, contents []byte) {
+		var pkg PackageJSON
+		if err := json.Unmarshal(contents, &pkg); err != nil {
+			return
+		}
+		params := analyzePackageJSON(&pkg)
+		if params == nil {
+			if _, ok := pkg.DevDependencies["vite"]; ok {
+				t.Fatal("vite project not recognised")
+			}
+			return
+		}
+		if params.PackageType == "" {
+			t.Fatal("no platform")
+		}
+		if !digits.MatchString(params.ViteMajorVer) || !digits.MatchString(params.MajorVer) {
+			t.Fatalf("bad major versions %q and %q", params.ViteMajorVer, params.MajorVer)
+		}
+		if _, ok := LookupPlatform(params.PackageType); !ok {
+			t.Fatalf("unknown platform %s", params.PackageType)
+		}
+	})
+}
+
+func TestAnalyzeNilPackageJSON(t *testing.T) {
+	if analyzePackageJSON(nil) != nil {
+		t.Error("nil package.json taken for a Vite project")
+	}
+}
@@ -0,0 +1,154 @@
+package vueglue
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// IndexOptions configures IndexTemplate.
+type IndexOptions struct {
+	// Path is the HTML page to load, relative to the dist
+	// directory in production, and to the dev server's root in
+	// development. Default is index.html.
+	Path string
+
+	// LeftDelim and RightDelim are the template's action
+	// delimiters. Change them if your page already contains
+	// "{{" (a Vue in-DOM template, say). Default is {{ and }}.
+	LeftDelim  string
+	RightDelim string
+
+	// Funcs are added to the template before it is parsed.
+	Funcs template.FuncMap
+
+	// Client fetches the page from the dev server.
+	// Default is http.DefaultClient.
+	Client *http.Client
+}
+
+// devURLRE finds the URLs of src and href attributes and of
+// module imports in the dev server's HTML, which have to be
+// pointed back at the dev server once the page is served by Go.
+var devURLRE = regexp.MustCompile(`(\b(?:src|href)=|\bfrom |\bimport\()(["'])([^"']*)(["'])`)
+
+// schemeRE matches URLs with a scheme (https:, data:, mailto:).
+var schemeRE = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// rewriteDevURLs points the page's root-relative and relative
+// URLs at the dev server, resolving relative ones against the
+// page's own directory there. In imports only ./ and ../ paths
+// are relative; anything else is a bare package specifier.
+func (vg *VueGlue) rewriteDevURLs(contents, page string) string {
+	dir := path.Dir("/" + strings.TrimPrefix(page, "/"))
+	return devURLRE.ReplaceAllStringFunc(contents, func(match string) string {
+		parts := devURLRE.FindStringSubmatch(match)
+		prefix, open, ref, end := parts[1], parts[2], parts[3], parts[4]
+		isImport := !strings.HasSuffix(prefix, "=")
+		switch {
+		case ref == "", strings.HasPrefix(ref, "//"), strings.HasPrefix(ref, "#"),
+			strings.Contains(ref, "{{"), schemeRE.MatchString(ref):
+			return match
+		case strings.HasPrefix(ref, "/"):
+		case isImport && !strings.HasPrefix(ref, "./") && !strings.HasPrefix(ref, "../"):
+			return match
+		default:
+			// keep any query or fragment out of path.Join
+			rest := ""
+			if i := strings.IndexAny(ref, "?#"); i >= 0 {
+				ref, rest = ref[:i], ref[i:]
+			}
+			ref = path.Join(dir, ref) + rest
+		}
+		return prefix + open + vg.baseURL + ref + end
+	})
+}
+
+// IndexTemplate loads the HTML page Vite generates for your app,
+// and parses it as an html/template, so you can use it as your
+// page shell and inject server data into it:
+//
+//	<div id="app" data-user="{{ .User }}"></div>
+//
+// In production this is dist/index.html, with the tags Vite
+// inserted for the build. In development the page is fetched
+// through the dev server, so it gets the same transforms (the
+// Vite client, plugin preambles) as a page Vite serves itself;
+// root-relative and relative URLs in it (./src/main.ts, say) are
+// rewritten to point at the dev server.
+//
+// A production template never changes, so load it once. In
+// development, loading it for each request picks up edits to
+// index.html.
+func (vg *VueGlue) IndexTemplate(ctx context.Context, opts IndexOptions) (*template.Template, error) {
+	page := opts.Path
+	if page == "" {
+		page = "index.html"
+	}
+
+	var html string
+	if vg.environment == "development" {
+		contents, err := vg.fetchDevPage(ctx, page, opts.Client)
+		if err != nil {
+			return nil, err
+		}
+		html = vg.rewriteDevURLs(contents, page)
+	} else {
+		if vg.distFS == nil {
+			// a glue from ParseManifest has no build to read
+			return nil, ErrManifestDNF
+		}
+		contents, err := fs.ReadFile(vg.distFS, path.Join(vg.assetPath, page))
+		if err != nil {
+			return nil, err
+		}
+		html = string(contents)
+	}
+
+	tmpl := template.New(path.Base(page))
+	if opts.LeftDelim != "" || opts.RightDelim != "" {
+		tmpl = tmpl.Delims(opts.LeftDelim, opts.RightDelim)
+	}
+	if opts.Funcs != nil {
+		tmpl = tmpl.Funcs(opts.Funcs)
+	}
+	return tmpl.Parse(html)
+}
+
+// fetchDevPage gets an HTML page from the dev server.
+func (vg *VueGlue) fetchDevPage(ctx context.Context, page string, client *http.Client) (string, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	url := vg.baseURL + "/"
+	if page != "index.html" {
+		url += strings.TrimPrefix(page, "/")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("dev server returned %s for %s", resp.Status, url)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,238 @@
+package vueglue
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const builtIndex = `<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <title>{{ .Title }}</title>
+    <script type="module" crossorigin src="/assets/index.4f1c2a9e.js"></script>
+    <link rel="stylesheet" href="/assets/index.0f2a382e.css">
+  </head>
+  <body>
+    <div id="app" data-user="{{ .User }}"></div>
+  </body>
+</html>
+`
+
+func TestIndexTemplateProduction(t *testing.T) {
+	dist := fstest.MapFS{
+		"dist/manifest.json": {Data: []byte(`{"index.html": {"file": "assets/index.4f1c2a9e.js", "src": "index.html", "isEntry": true}}`)},
+		"dist/index.html":    {Data: []byte(builtIndex)},
+		"dist/alt.html":      {Data: []byte(`<p>[[ .User ]] {{ not a template }}</p>`)},
+
+		"dist/assets/index.4f1c2a9e.js": {Data: []byte(`export {}`)},
+	}
+	glue, err := NewVueGlue(&ViteConfig{Environment: "production", FS: dist})
+	if err != nil {
+		t.Fatalf("could not create glue: %s", err)
+	}
+
+	tmpl, err := glue.IndexTemplate(context.Background(), IndexOptions{})
+	if err != nil {
+		t.Fatalf("template did not load: %s", err)
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]string{"Title": "Shop", "User": `"><script>`})
+	if err != nil {
+		t.Fatalf("template did not execute: %s", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<title>Shop</title>`,
+		`<script type="module" crossorigin src="/assets/index.4f1c2a9e.js"></script>`,
+		`<link rel="stylesheet" href="/assets/index.0f2a382e.css">`,
+		`data-user="&#34;&gt;&lt;script&gt;"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("page did not contain %q:\n%s", want, out)
+		}
+	}
+
+	tmpl, err = glue.IndexTemplate(context.Background(), IndexOptions{
+		Path:      "alt.html",
+		LeftDelim: "[[", RightDelim: "]]",
+	})
+	if err != nil {
+		t.Fatalf("template did not load: %s", err)
+	}
+	buf.Reset()
+	if err := tmpl.Execute(&buf, map[string]string{"User": "bob"}); err != nil {
+		t.Fatalf("template did not execute: %s", err)
+	}
+	if buf.String() != `<p>bob {{ not a template }}</p>` {
+		t.Errorf("unexpected page: %s", buf.String())
+	}
+
+	if _, err := glue.IndexTemplate(context.Background(), IndexOptions{Path: "missing.html"}); err == nil {
+		t.Errorf("expected a missing page to fail")
+	}
+
+	// A glue from ParseManifest has no build to read the page from.
+	parsed, err := ParseManifest(dist["dist/manifest.json"].Data)
+	if err != nil {
+		t.Fatalf("could not parse manifest: %s", err)
+	}
+	if _, err := parsed.IndexTemplate(context.Background(), IndexOptions{}); !errors.Is(err, ErrManifestDNF) {
+		t.Errorf("expected ErrManifestDNF, got %v", err)
+	}
+}
+
+const devIndex = `<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <script type="module" src="/@vite/client"></script>
+    <script type="module">
+import RefreshRuntime from "/@react-refresh"
+RefreshRuntime.injectIntoGlobalHook(window)
+</script>
+    <link rel="icon" href="/vite.svg" />
+    <link rel="preconnect" href="//fonts.example.com" />
+    <title>{{ .Title }}</title>
+  </head>
+  <body>
+    <div id="root"></div>
+    <script type="module" src="/src/main.tsx"></script>
+  </body>
+</html>
+`
+
+func TestIndexTemplateDevelopment(t *testing.T) {
+	var requested []string
+	vite := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(devIndex))
+	}))
+	defer vite.Close()
+	viteURL, _ := url.Parse(vite.URL)
+
+	glue, err := NewVueGlue(&ViteConfig{
+		Environment:     "development",
+		FS:              os.DirFS("testdata"),
+		EntryPoint:      "src/main.tsx",
+		DevServerDomain: viteURL.Hostname(),
+		DevServerPort:   viteURL.Port(),
+	})
+	if err != nil {
+		t.Fatalf("could not create glue: %s", err)
+	}
+
+	tmpl, err := glue.IndexTemplate(context.Background(), IndexOptions{})
+	if err != nil {
+		t.Fatalf("template did not load: %s", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{"Title": "Dev"}); err != nil {
+		t.Fatalf("template did not execute: %s", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<script type="module" src="` + vite.URL + `/@vite/client"></script>`,
+		`import RefreshRuntime from "` + vite.URL + `/@react-refresh"`,
+		`<link rel="icon" href="` + vite.URL + `/vite.svg" />`,
+		`<link rel="preconnect" href="//fonts.example.com" />`,
+		`<script type="module" src="` + vite.URL + `/src/main.tsx"></script>`,
+		`<title>Dev</title>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("page did not contain %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := glue.IndexTemplate(context.Background(), IndexOptions{Path: "nested/page.html"}); err == nil {
+		t.Errorf("expected a 404 from the dev server to fail")
+	}
+	if requested[len(requested)-1] != "/nested/page.html" {
+		t.Errorf("unexpected request path %v", requested)
+	}
+}
+
+const devRelativeIndex = `<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <link rel="stylesheet" href="src/style.css" />
+    <link rel="icon" href="./favicon.svg?v=2" />
+    <link rel="canonical" href="https://example.com/" />
+    <script type="module">
+import { createApp } from "vue"
+import App from "./src/App.vue"
+</script>
+  </head>
+  <body>
+    <a href="#top">Top</a>
+    <script type="module" src="./src/main.ts"></script>
+  </body>
+</html>
+`
+
+func TestIndexTemplateDevelopmentRelative(t *testing.T) {
+	vite := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" && r.URL.Path != "/admin/index.html" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(devRelativeIndex))
+	}))
+	defer vite.Close()
+	viteURL, _ := url.Parse(vite.URL)
+
+	glue, err := NewVueGlue(&ViteConfig{
+		Environment:     "development",
+		FS:              os.DirFS("testdata"),
+		EntryPoint:      "src/main.ts",
+		DevServerDomain: viteURL.Hostname(),
+		DevServerPort:   viteURL.Port(),
+	})
+	if err != nil {
+		t.Fatalf("could not create glue: %s", err)
+	}
+
+	tests := []struct {
+		page, dir string
+	}{
+		{"", ""},
+		{"admin/index.html", "/admin"},
+	}
+	for _, tt := range tests {
+		tmpl, err := glue.IndexTemplate(context.Background(), IndexOptions{Path: tt.page})
+		if err != nil {
+			t.Fatalf("%q: template did not load: %s", tt.page, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, nil); err != nil {
+			t.Fatalf("%q: template did not execute: %s", tt.page, err)
+		}
+		out := buf.String()
+		base := vite.URL + tt.dir
+		for _, want := range []string{
+			`<link rel="stylesheet" href="` + base + `/src/style.css" />`,
+			`<link rel="icon" href="` + base + `/favicon.svg?v=2" />`,
+			`<link rel="canonical" href="https://example.com/" />`,
+			`import { createApp } from "vue"`,
+			`import App from "` + base + `/src/App.vue"`,
+			`<a href="#top">Top</a>`,
+			`<script type="module" src="` + base + `/src/main.ts"></script>`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("%q: page did not contain %q:\n%s", tt.page, want, out)
+			}
+		}
+	}
+}
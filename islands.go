@@ -0,0 +1,247 @@
+package vueglue
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path"
+	"sync"
+)
+
+// IslandSet renders islands: small, independently mounted JS
+// components placed into a page rendered by a Go template. An
+// island is a Vite entry module (listed in rollupOptions.input)
+// that mounts itself into every placeholder carrying its name:
+//
+//	// src/islands/cart.js
+//	import { createApp } from 'vue';
+//	import Cart from '../widgets/Cart.vue';
+//
+//	for (const el of document.querySelectorAll('[data-vite-island="src/islands/cart.js"]')) {
+//	  const data = document.getElementById(el.dataset.viteProps);
+//	  createApp(Cart, data ? JSON.parse(data.textContent) : {}).mount(el);
+//	}
+//
+// The key passed to Island must be such a mount entry, not the
+// component itself: a .vue or .svelte file cannot mount itself,
+// so it is refused, as is (in development, when the Vite config
+// lists its inputs) a module that is not one of the inputs.
+//
+// Each call to Island emits a placeholder and the island's props.
+// The entry script (and in production its CSS and preloads) are
+// emitted only the first time they are needed, so an IslandSet
+// should live for a single page render. In development, the first
+// island script is preceded by the platform's dev preamble (see
+// Platform.DevPreamble), which React's Fast Refresh needs. Use
+// VueGlue.Islands to get one.
+type IslandSet struct {
+	vg    *VueGlue
+	snap  *Snapshot
+	nonce string
+
+	mu        sync.Mutex
+	count     int
+	emitted   map[string]bool
+	files     map[string]bool
+	preambled bool
+}
+
+// Islands returns a fresh IslandSet for one page render. nonce
+// is added to the script and link tags it emits; pass "" if you
+// don't use a Content Security Policy.
+func (vg *VueGlue) Islands(nonce string) *IslandSet {
+	return &IslandSet{
+		vg:      vg,
+		snap:    vg.state.Load(),
+		nonce:   nonce,
+		emitted: map[string]bool{},
+		files:   map[string]bool{},
+	}
+}
+
+// FuncMap provides the vite_island template function when
+// parsing templates. The placeholder it installs fails if
+// called; replace it for each render with IslandSet.FuncMap:
+//
+//	tmpl := template.Must(template.New("page").Funcs(glue.FuncMap()).ParseFiles("page.tmpl"))
+//	...
+//	page, _ := tmpl.Clone()
+//	page.Funcs(glue.Islands(nonce).FuncMap()).Execute(w, data)
+func (vg *VueGlue) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"vite_island": func(string, interface{}) (template.HTML, error) {
+			return "", ErrNoIslandSet
+		},
+		"vite_entry": func(string) (template.HTML, error) {
+			return "", ErrNoIslandSet
+		},
+	}
+}
+
+// FuncMap returns the set's template functions:
+//
+//	{{ vite_island "src/islands/cart.js" .CartProps }}
+//	{{ vite_entry "src/print.scss" }}
+func (is *IslandSet) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"vite_island": is.Island,
+		"vite_entry":  is.Entry,
+	}
+}
+
+// Entry renders the tags for an entry without a placeholder,
+// for entries that need no mount point: a stylesheet built as
+// its own entry, say, or a script that runs on its own. Like
+// Island, it links each file only once per set.
+func (is *IslandSet) Entry(key string) (template.HTML, error) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+	if is.emitted[key] {
+		return "", nil
+	}
+	tags, err := is.entryTags(key)
+	if err != nil {
+		return "", err
+	}
+	is.emitted[key] = true
+	return template.HTML(tags), nil
+}
+
+// Island renders one island placeholder for the entry module
+// key (the module's path in the JS project, which is also its
+// key in manifest.json), with props serialized as JSON.
+func (is *IslandSet) Island(key string, props interface{}) (template.HTML, error) {
+	is.mu.Lock()
+	is.count++
+	id := fmt.Sprintf("vite-island-%d", is.count)
+	var html bytes.Buffer
+	if !is.emitted[key] {
+		scripts, err := is.entryTags(key)
+		if err != nil {
+			is.mu.Unlock()
+			return "", err
+		}
+		is.emitted[key] = true
+		html.WriteString(scripts)
+	}
+	is.mu.Unlock()
+
+	propsID := id + "-props"
+	propsTag, err := PropsTag(propsID, props, is.nonce)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(
+		&html,
+		`<div id="%s" data-vite-island="%s" data-vite-props="%s"></div>`,
+		id,
+		template.HTMLEscapeString(key),
+		propsID,
+	)
+	html.WriteString(string(propsTag))
+
+	return template.HTML(html.String()), nil
+}
+
+// componentExts are single file components, which only a mount
+// entry can put on a page.
+var componentExts = map[string]bool{
+	".vue":    true,
+	".svelte": true,
+}
+
+// checkEntry refuses keys that cannot be islands or entries:
+// components, and in development, modules the Vite config's
+// rollupOptions.input does not list. In production the manifest
+// does the second check.
+func (is *IslandSet) checkEntry(key string) error {
+	if componentExts[path.Ext(key)] {
+		return fmt.Errorf("%w: %s is a component; pass the entry module that mounts it", ErrUnknownEntry, key)
+	}
+	defaults := is.vg.config.DevDefaults
+	if is.vg.environment != "development" || defaults == nil || defaults.EntrySource != EntrySourceViteConfig {
+		return nil
+	}
+	for _, entry := range defaults.EntryPoints {
+		if entry == key {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not in build.rollupOptions.input", ErrUnknownEntry, key)
+}
+
+// entryTags renders the script, preload and CSS tags for an
+// island entry, skipping files an earlier island already
+// linked. Stylesheet entries get a stylesheet link instead.
+// Call it with is.mu held.
+func (is *IslandSet) entryTags(key string) (string, error) {
+	if err := is.checkEntry(key); err != nil {
+		return "", err
+	}
+	var tags bytes.Buffer
+	if is.vg.environment == "development" {
+		if isStylesheet(key) {
+			is.stylesheetTag(&tags, is.vg.baseURL+"/"+key)
+			return tags.String(), nil
+		}
+		if !is.preambled {
+			preamble, err := is.vg.RenderPreamble(is.nonce)
+			if err != nil {
+				return "", err
+			}
+			is.preambled = true
+			tags.WriteString(string(preamble))
+		}
+		is.scriptTag(&tags, is.vg.baseURL+"/"+key)
+		return tags.String(), nil
+	}
+
+	chunk, ok := is.snap.Manifest[key]
+	if !ok || !chunk.IsEntry {
+		return "", fmt.Errorf("%w: %s", ErrUnknownEntry, key)
+	}
+	if chunk.IsStylesheet() {
+		if !is.files[chunk.File] {
+			is.files[chunk.File] = true
+			is.stylesheetTag(&tags, "/"+chunk.File)
+		}
+		return tags.String(), nil
+	}
+	imports, css := is.snap.Manifest.EntryFiles(key)
+	for _, file := range css {
+		if !is.files[file] {
+			is.files[file] = true
+			is.stylesheetTag(&tags, "/"+file)
+		}
+	}
+	for _, file := range imports {
+		if !is.files[file] {
+			is.files[file] = true
+			is.linkTag(&tags, "modulepreload", "/"+file)
+		}
+	}
+	is.scriptTag(&tags, "/"+chunk.File)
+	return tags.String(), nil
+}
+
+// scriptTag writes a module script tag for src.
+func (is *IslandSet) scriptTag(tags *bytes.Buffer, src string) {
+	tags.WriteString(`<script type="module" crossorigin src="`)
+	tags.WriteString(template.HTMLEscapeString(src))
+	tags.WriteString(`"`)
+	writeNonce(tags, is.nonce)
+	tags.WriteString(`></script>`)
+}
+
+// stylesheetTag writes a stylesheet link for href.
+func (is *IslandSet) stylesheetTag(tags *bytes.Buffer, href string) {
+	is.linkTag(tags, "stylesheet", href)
+}
+
+// linkTag writes a link of type rel to href.
+func (is *IslandSet) linkTag(tags *bytes.Buffer, rel, href string) {
+	fmt.Fprintf(tags, `<link rel="%s" href="%s"`, rel, template.HTMLEscapeString(href))
+	writeNonce(tags, is.nonce)
+	tags.WriteString(`>`)
+}
@@ -0,0 +1,175 @@
+package vueglue
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const islandPage = `{{ vite_island "src/islands/cart.js" .Cart }}
+{{ vite_island "src/islands/counter.js" .Count }}
+{{ vite_island "src/islands/cart.js" .Cart }}`
+
+func renderIslands(t *testing.T, glue *VueGlue, nonce string) string {
+	t.Helper()
+	tmpl := template.Must(template.New("page").Funcs(glue.FuncMap()).Parse(islandPage))
+	page, err := tmpl.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	err = page.Funcs(glue.Islands(nonce).FuncMap()).Execute(&buf, map[string]interface{}{
+		"Cart":  map[string]int{"items": 2},
+		"Count": 5,
+	})
+	if err != nil {
+		t.Fatalf("islands did not render: %s", err)
+	}
+	return buf.String()
+}
+
+func TestIslandsDevelopment(t *testing.T) {
+	glue, err := initializeVueGlue(nil)
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+
+	out := renderIslands(t, glue, "n0nce")
+
+	cartScript := `<script type="module" crossorigin src="http://localhost:3000/src/islands/cart.js" nonce="n0nce"></script>`
+	if n := strings.Count(out, cartScript); n != 1 {
+		t.Errorf("cart script rendered %d times:\n%s", n, out)
+	}
+	if !strings.Contains(out, `src="http://localhost:3000/src/islands/counter.js"`) {
+		t.Errorf("counter script missing:\n%s", out)
+	}
+	for _, want := range []string{
+		`<div id="vite-island-1" data-vite-island="src/islands/cart.js" data-vite-props="vite-island-1-props"></div>`,
+		`<script type="application/json" id="vite-island-1-props" nonce="n0nce">{"items":2}</script>`,
+		`<script type="application/json" id="vite-island-2-props" nonce="n0nce">5</script>`,
+		`<div id="vite-island-3" data-vite-island="src/islands/cart.js"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output did not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestIslandsProduction(t *testing.T) {
+	contents, err := os.ReadFile("testdata/manifest-islands.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// With several entries, the main one is the one index.html
+	// loads.
+	glue, err := NewVueGlue(&ViteConfig{
+		Environment: "production",
+		FS: fstest.MapFS{
+			"index.html":         {Data: []byte(`<script type="module" src="/src/main.ts"></script>`)},
+			"dist/manifest.json": {Data: contents},
+		},
+		SkipVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("manifest did not parse: %s", err)
+	}
+	if glue.MainModule() != "assets/main.4f1c2a9e.js" {
+		t.Errorf("unexpected main module %s", glue.MainModule())
+	}
+	if glue.Config().EntryPoint != "src/main.ts" {
+		t.Errorf("unexpected entry point %q", glue.Config().EntryPoint)
+	}
+
+	// Without index.html, there is no telling which it is.
+	if _, err := ParseManifest(contents); !errors.Is(err, ErrAmbiguousEntryPoint) {
+		t.Errorf("expected ErrAmbiguousEntryPoint, got %v", err)
+	}
+
+	out := renderIslands(t, glue, "n0nce")
+
+	for _, want := range []string{
+		`<link rel="stylesheet" href="/assets/vendor.1f2e3d4c.css" nonce="n0nce">`,
+		`<link rel="stylesheet" href="/assets/cart.5e6f7a8b.css" nonce="n0nce">`,
+		`<link rel="modulepreload" href="/assets/money.0a1b2c3d.js" nonce="n0nce">`,
+		`<script type="module" crossorigin src="/assets/cart.7d3e1b20.js" nonce="n0nce"></script>`,
+		`<script type="module" crossorigin src="/assets/counter.9c8b7a6d.js" nonce="n0nce"></script>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output did not contain %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "cart.7d3e1b20.js"); n != 1 {
+		t.Errorf("cart script rendered %d times:\n%s", n, out)
+	}
+	// counter shares the vendor chunk with cart, so it is only
+	// linked once.
+	if n := strings.Count(out, `<link rel="modulepreload" href="/assets/vendor.b43f27d7.js" nonce="n0nce">`); n != 1 {
+		t.Errorf("vendor preloaded %d times:\n%s", n, out)
+	}
+
+	_, err = glue.Islands("").Island("src/islands/missing.js", nil)
+	if !errors.Is(err, ErrUnknownEntry) {
+		t.Errorf("expected ErrUnknownEntry, got %v", err)
+	}
+	_, err = glue.Islands("").Island("_vendor.b43f27d7.js", nil)
+	if !errors.Is(err, ErrUnknownEntry) {
+		t.Errorf("expected non-entry chunk to be refused, got %v", err)
+	}
+	_, err = glue.Islands("").Island("src/widgets/Cart.vue", nil)
+	if !errors.Is(err, ErrUnknownEntry) || !strings.Contains(err.Error(), "mounts it") {
+		t.Errorf("expected a component to be refused, got %v", err)
+	}
+}
+
+func TestIslandsDevelopmentReact(t *testing.T) {
+	glue, err := NewVueGlue(&ViteConfig{
+		Environment: "development",
+		FS: fstest.MapFS{
+			"package.json": {Data: []byte(`{"dependencies": {"react": "^18.2.0"}, "devDependencies": {"vite": "^5.0.0"}}`)},
+			"vite.config.js": {Data: []byte(`export default {
+  build: {
+    rollupOptions: {
+      input: ["src/main.tsx", "src/islands/cart.js", "src/islands/counter.js"],
+    },
+  },
+}`)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+
+	out := renderIslands(t, glue, "n0nce")
+
+	// The Fast Refresh preamble comes once, before the first island.
+	preamble := `/@react-refresh"`
+	if n := strings.Count(out, preamble); n != 1 {
+		t.Errorf("preamble rendered %d times:\n%s", n, out)
+	}
+	if strings.Index(out, preamble) > strings.Index(out, "src/islands/cart.js") {
+		t.Errorf("preamble rendered after the first island:\n%s", out)
+	}
+	if !strings.Contains(out, `<script type="module" nonce="n0nce">`) {
+		t.Errorf("preamble has no nonce:\n%s", out)
+	}
+
+	for _, key := range []string{"src/widgets/Cart.vue", "src/islands/missing.js"} {
+		if _, err := glue.Islands("").Island(key, nil); !errors.Is(err, ErrUnknownEntry) {
+			t.Errorf("%s: expected ErrUnknownEntry, got %v", key, err)
+		}
+	}
+}
+
+func TestIslandsNeedSet(t *testing.T) {
+	glue := prodTestGlue(t)
+	tmpl := template.Must(template.New("page").Funcs(glue.FuncMap()).Parse(islandPage))
+	err := tmpl.Execute(&bytes.Buffer{}, nil)
+	if !errors.Is(err, ErrNoIslandSet) {
+		t.Errorf("expected ErrNoIslandSet, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package vueglue
+
+import (
+	"path"
+	"strings"
+)
+
+// @vitejs/plugin-legacy builds a second, SystemJS copy of each
+// entry for browsers without native ESM support, plus polyfill
+// chunks. In the manifest, the legacy copy of "src/main.ts" is
+// "src/main-legacy.ts", and the polyfills live under a virtual
+// "vite/legacy-polyfills-legacy" key (and "vite/legacy-polyfills"
+// for modernPolyfills), with a "../../" prefix in older versions.
+const (
+	legacySuffix       = "-legacy"
+	legacyPolyfillsKey = "legacy-polyfills"
+)
+
+// The inline scripts plugin-legacy adds to the HTML it generates.
+const (
+	// Safari 10.1 supports modules, but does not support the
+	// nomodule attribute; keep it from running the legacy code.
+	safari10NoModuleFix = `!function(){var e=document,t=e.createElement("script");if(!("noModule"in t)&&"onbeforeload"in t){var n=!1;e.addEventListener("beforeload",(function(e){if(e.target===t)n=!0;else if(!e.target.hasAttribute("nomodule")||!n)return;e.preventDefault()}),!0),t.type="module",t.src=".",e.head.appendChild(t),t.remove()}}();`
+
+	// Browsers that support modules but not dynamic import or
+	// import.meta fail to run this, and so are not "modern".
+	detectModernBrowserCode = `import.meta.url;import("_").catch(()=>1);(async function*(){})().next();if(location.protocol!="file:"){window.__vite_is_modern_browser=true}`
+
+	// ...and those browsers get the legacy build loaded instead.
+	dynamicFallbackInlineCode = `!function(){if(window.__vite_is_modern_browser)return;console.warn("vite: loading legacy chunks, syntax error above and the same error below should be ignored");var e=document.getElementById("vite-legacy-polyfill"),n=document.createElement("script");n.src=e.src,n.onload=function(){System.import(document.getElementById('vite-legacy-entry').getAttribute('data-src'))},document.body.appendChild(n)}();`
+
+	systemJSInlineCode = `System.import(document.getElementById('vite-legacy-entry').getAttribute('data-src'))`
+)
+
+// legacyTags is added to the production tags when the build
+// has legacy chunks.
+const legacyTags = `
+	<script type="module"` + nonceAttr + `>` + detectModernBrowserCode + `</script>
+	<script type="module"` + nonceAttr + `>` + dynamicFallbackInlineCode + `</script>
+	<script nomodule` + nonceAttr + `>` + safari10NoModuleFix + `</script>
+	<script nomodule crossorigin id="vite-legacy-polyfill" src="/{{ .LegacyPolyfills }}"` + nonceAttr + `></script>
+	<script nomodule crossorigin id="vite-legacy-entry" data-src="/{{ .LegacyModule }}"` + nonceAttr + `>` + systemJSInlineCode + `</script>
+	`
+
+// hasLegacySuffix reports whether key is named the way
+// plugin-legacy names its chunks.
+func hasLegacySuffix(key string) bool {
+	stem := strings.TrimSuffix(key, path.Ext(key))
+	return strings.HasSuffix(stem, legacySuffix)
+}
+
+// IsLegacy reports whether the chunk at key was built by
+// plugin-legacy, either as a legacy entry or as the legacy
+// polyfills. Being named like one is not enough, since an app
+// can have a src/my-legacy.ts of its own: the manifest must also
+// have the modern chunk it is a copy of, or the polyfills that
+// plugin-legacy always adds.
+func (m Manifest) IsLegacy(key string) bool {
+	if !hasLegacySuffix(key) {
+		return false
+	}
+	ext := path.Ext(key)
+	modern := strings.TrimSuffix(strings.TrimSuffix(key, ext), legacySuffix) + ext
+	if _, ok := m[modern]; ok {
+		return true
+	}
+	return m.LegacyPolyfills() != nil
+}
+
+// IsPolyfills reports whether the chunk holds plugin-legacy's
+// polyfills, legacy or modern.
+func (c *Chunk) IsPolyfills() bool {
+	base := strings.TrimSuffix(path.Base(c.Key), legacySuffix)
+	return base == legacyPolyfillsKey
+}
+
+// LegacyEntry returns the legacy build of an entry, if any.
+func (m Manifest) LegacyEntry(key string) *Chunk {
+	ext := path.Ext(key)
+	return m[strings.TrimSuffix(key, ext)+legacySuffix+ext]
+}
+
+// LegacyPolyfills returns the polyfills chunk for legacy
+// browsers, if the build has one.
+func (m Manifest) LegacyPolyfills() *Chunk {
+	return m.polyfills(true)
+}
+
+// ModernPolyfills returns the polyfills chunk for modern
+// browsers (plugin-legacy's modernPolyfills option), if any.
+func (m Manifest) ModernPolyfills() *Chunk {
+	return m.polyfills(false)
+}
+
+func (m Manifest) polyfills(legacy bool) *Chunk {
+	for _, key := range m.Keys() {
+		chunk := m[key]
+		if chunk.IsPolyfills() && hasLegacySuffix(key) == legacy {
+			return chunk
+		}
+	}
+	return nil
+}
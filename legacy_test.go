@@ -0,0 +1,101 @@
+package vueglue
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLegacyManifest(t *testing.T) {
+	contents, err := os.ReadFile("testdata/manifest-legacy.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	glue, err := ParseManifest(contents)
+	if err != nil {
+		t.Fatalf("manifest did not parse: %s", err)
+	}
+
+	snap := glue.Snapshot()
+	if snap.MainModule != "assets/index.c0a1b2c3.js" {
+		t.Errorf("legacy chunk picked as main module: %s", snap.MainModule)
+	}
+	if snap.LegacyModule != "assets/index-legacy.1f0a5c3e.js" {
+		t.Errorf("legacy module: %q", snap.LegacyModule)
+	}
+	if snap.LegacyPolyfills != "assets/polyfills-legacy.b7d4d3f3.js" {
+		t.Errorf("legacy polyfills: %q", snap.LegacyPolyfills)
+	}
+	if snap.ModernPolyfills != "assets/polyfills.6b4a1e4d.js" {
+		t.Errorf("modern polyfills: %q", snap.ModernPolyfills)
+	}
+
+	tags, err := glue.RenderTagsWithNonce("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(tags)
+	ordered := []string{
+		`<script type="module" crossorigin src="/assets/polyfills.6b4a1e4d.js" nonce="abc"></script>`,
+		`<script type="module" crossorigin src="/assets/index.c0a1b2c3.js" nonce="abc"></script>`,
+		`window.__vite_is_modern_browser=true`,
+		`System.import(document.getElementById('vite-legacy-entry')`,
+		`<script nomodule nonce="abc">`,
+		`<script nomodule crossorigin id="vite-legacy-polyfill" src="/assets/polyfills-legacy.b7d4d3f3.js" nonce="abc"></script>`,
+		`<script nomodule crossorigin id="vite-legacy-entry" data-src="/assets/index-legacy.1f0a5c3e.js" nonce="abc">System.import(`,
+	}
+	pos := 0
+	for _, want := range ordered {
+		idx := strings.Index(html[pos:], want)
+		if idx < 0 {
+			t.Fatalf("missing or out of order %q in:\n%s", want, html)
+		}
+		pos += idx + len(want)
+	}
+	if strings.Contains(html, "vendor-legacy") {
+		t.Error("legacy imports should not be preloaded as modules")
+	}
+}
+
+func TestNoLegacyTags(t *testing.T) {
+	glue := prodTestGlue(t)
+	tags, err := glue.RenderTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(tags), "nomodule") {
+		t.Error("legacy tags rendered for a modern-only build")
+	}
+}
+
+func TestLegacyNamedEntry(t *testing.T) {
+	// Without plugin-legacy, a -legacy name is just a name.
+	manifest := Manifest{
+		"src/my-legacy.ts": {Key: "src/my-legacy.ts", File: "assets/my-legacy.js", IsEntry: true},
+	}
+	if manifest.IsLegacy("src/my-legacy.ts") {
+		t.Error("app entry classified as plugin-legacy output")
+	}
+	snap, err := snapshotFromManifest(manifest, "")
+	if err != nil {
+		t.Fatalf("entry not used: %s", err)
+	}
+	if snap.MainModule != "assets/my-legacy.js" || snap.LegacyModule != "" {
+		t.Errorf("unexpected snapshot: %+v", snap)
+	}
+
+	contents, err := os.ReadFile("testdata/manifest-legacy.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	glue, err := ParseManifest(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy := glue.Snapshot().Manifest
+	for _, key := range legacy.Keys() {
+		if hasLegacySuffix(key) && !legacy.IsLegacy(key) {
+			t.Errorf("%s not classified as legacy", key)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package vueglue
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// Vite 5 moved its manifests into a .vite directory inside the
+// build directory, so that they are not deployed with the
+// assets by accident. Earlier versions write them to the build
+// directory itself.
+const viteMetaDir = ".vite"
+
+// manifestCandidates lists where a manifest called name may be,
+// newest layout first.
+func manifestCandidates(assetsPath, name string) []string {
+	return []string{
+		assetsPath + "/" + viteMetaDir + "/" + name,
+		assetsPath + "/" + name,
+	}
+}
+
+// findManifest reads the build manifest from fsys. ManifestPath
+// is used if set; otherwise the manifest is looked for where
+// each version of Vite puts it.
+func (vc *ViteConfig) findManifest(fsys fs.FS) (string, []byte, error) {
+	if vc.ManifestPath != "" {
+		contents, err := fs.ReadFile(fsys, vc.ManifestPath)
+		return vc.ManifestPath, contents, err
+	}
+
+	candidates := manifestCandidates(vc.AssetsPath, "manifest.json")
+	for _, name := range candidates {
+		contents, err := fs.ReadFile(fsys, name)
+		if err == nil {
+			return name, contents, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", nil, err
+		}
+	}
+	hint := ""
+	if _, err := fs.Stat(fsys, vc.AssetsPath+"/"+viteMetaDir); err != nil {
+		// go:embed skips dot directories unless told otherwise
+		hint = "; if the build is embedded, embed it with the all: prefix so that " + viteMetaDir + " is included"
+	}
+	return "", nil, fmt.Errorf(
+		"%w: looked in %s (%w)%s",
+		ErrManifestNotFound,
+		strings.Join(candidates, ", "),
+		fs.ErrNotExist,
+		hint,
+	)
+}
+
+// ReadManifest finds and parses the manifest of the build in the
+// JS project fsys is rooted at, the way NewVueGlue does: in
+// config's ManifestPath, or else in its AssetsPath (default
+// dist). It also returns where the manifest was found.
+func ReadManifest(fsys fs.FS, config ViteConfig) (Manifest, string, error) {
+	if err := config.SetProductionDefaults(); err != nil {
+		return nil, "", err
+	}
+	name, contents, err := config.findManifest(fsys)
+	if err != nil {
+		return nil, "", err
+	}
+	var target manifestTarget
+	manifest, err := target.parseChunks(contents)
+	if err != nil {
+		return nil, "", err
+	}
+	return manifest, name, nil
+}
+
+// ssrManifestFile is where the SSR manifest is: SSRManifestPath
+// if set, else whichever of the usual places has one. A build
+// without one is fine, so this falls back to the older layout.
+func (vc *ViteConfig) ssrManifestFile(fsys fs.FS) string {
+	if vc.SSRManifestPath != "" {
+		return vc.SSRManifestPath
+	}
+	candidates := manifestCandidates(vc.AssetsPath, "ssr-manifest.json")
+	for _, name := range candidates {
+		if _, err := fs.Stat(fsys, name); err == nil {
+			return name
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// ManifestPath is where the manifest was found in DistFS.
+// Empty in development.
+func (vg *VueGlue) ManifestPath() string {
+	return vg.manifestFile
+}
@@ -0,0 +1,186 @@
+package vueglue
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestManifestVersions(t *testing.T) {
+	tests := []struct {
+		version    string
+		manifest   string
+		mainModule string
+		imports    []string
+		css        []string
+		name       string
+		dynamic    bool
+	}{
+		{"2", "dist/manifest.json", "assets/main.9e2e52ce.js", []string{"assets/vendor.b43f27d7.js"}, []string{"assets/main.0f2a382e.css"}, "", false},
+		{"3", "dist/manifest.json", "assets/main.6c1f0a3b.js", []string{"assets/vendor.1e9d8c7b.js"}, []string{"assets/main.2b3c4d5e.css"}, "", true},
+		{"4", "dist/manifest.json", "assets/main-4ed993c7.js", []string{"assets/vendor-1e9d8c7b.js"}, []string{"assets/main-2b3c4d5e.css"}, "", true},
+		{"5", "dist/.vite/manifest.json", "assets/main-BRBmoGS9.js", []string{"assets/vendor-BxA3e2Qk.js"}, []string{"assets/main-DiwrgTda.css"}, "main", true},
+		{"6", "dist/.vite/manifest.json", "assets/main-C7wZp0Qe.js", []string{"assets/vendor-D4fQ8m2L.js"}, []string{"assets/main-Dk2n8XyR.css"}, "main", true},
+	}
+
+	for _, test := range tests {
+		t.Run("vite"+test.version, func(t *testing.T) {
+			glue, err := NewVueGlue(&ViteConfig{
+				Environment: "production",
+				FS:          os.DirFS("testdata/builds/vite" + test.version),
+				EntryPoint:  "src/main.ts",
+			})
+			if err != nil {
+				t.Fatalf("glue did not initialize: %s", err)
+			}
+			if glue.ManifestPath() != test.manifest {
+				t.Errorf("manifest found at %q, want %q", glue.ManifestPath(), test.manifest)
+			}
+
+			snap := glue.Snapshot()
+			if snap.MainModule != test.mainModule {
+				t.Errorf("main module %q, want %q", snap.MainModule, test.mainModule)
+			}
+			if !reflect.DeepEqual(snap.Imports, test.imports) {
+				t.Errorf("imports %v, want %v", snap.Imports, test.imports)
+			}
+			if !reflect.DeepEqual(snap.CSSModule, test.css) {
+				t.Errorf("css %v, want %v", snap.CSSModule, test.css)
+			}
+			if name := snap.Manifest["src/main.ts"].Name; name != test.name {
+				t.Errorf("entry name %q, want %q", name, test.name)
+			}
+			if dynamic := snap.Manifest["src/views/About.vue"].IsDynamicEntry; dynamic != test.dynamic {
+				t.Errorf("isDynamicEntry %v, want %v", dynamic, test.dynamic)
+			}
+		})
+	}
+}
+
+func TestManifestPath(t *testing.T) {
+	manifest := &fstest.MapFile{Data: []byte(`{"src/main.ts": {"file": "assets/main.js", "isEntry": true}}`)}
+	stale := &fstest.MapFile{Data: []byte(`{"src/main.ts": {"file": "assets/stale.js", "isEntry": true}}`)}
+	bundle := &fstest.MapFile{Data: []byte(`export {}`)}
+
+	tests := []struct {
+		name     string
+		fsys     fstest.MapFS
+		path     string
+		expected string
+	}{
+		{
+			name:     "newer layout wins",
+			fsys:     fstest.MapFS{"dist/.vite/manifest.json": manifest, "dist/manifest.json": stale, "dist/assets/main.js": bundle},
+			expected: "dist/.vite/manifest.json",
+		},
+		{
+			name:     "override",
+			fsys:     fstest.MapFS{"build/meta.json": manifest, "dist/manifest.json": stale, "dist/assets/main.js": bundle},
+			path:     "build/meta.json",
+			expected: "build/meta.json",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			glue, err := NewVueGlue(&ViteConfig{
+				Environment:  "production",
+				FS:           test.fsys,
+				ManifestPath: test.path,
+			})
+			if err != nil {
+				t.Fatalf("glue did not initialize: %s", err)
+			}
+			if glue.ManifestPath() != test.expected {
+				t.Errorf("manifest found at %q, want %q", glue.ManifestPath(), test.expected)
+			}
+			if glue.MainModule() != "assets/main.js" {
+				t.Errorf("wrong manifest read: %s", glue.MainModule())
+			}
+		})
+	}
+
+	_, err := NewVueGlue(&ViteConfig{
+		Environment: "production",
+		FS:          fstest.MapFS{"dist/index.html": &fstest.MapFile{}},
+	})
+	if !errors.Is(err, ErrManifestNotFound) || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "all: prefix") {
+		t.Errorf("expected a hint about go:embed, got %v", err)
+	}
+
+	_, err = NewVueGlue(&ViteConfig{
+		Environment: "production",
+		FS:          fstest.MapFS{"dist/.vite/ssr-manifest.json": &fstest.MapFile{}},
+	})
+	if !errors.Is(err, ErrManifestNotFound) || strings.Contains(err.Error(), "all: prefix") {
+		t.Errorf("expected a not found error without the hint, got %v", err)
+	}
+}
+
+func TestManifestNotServed(t *testing.T) {
+	glue, err := NewVueGlue(&ViteConfig{
+		Environment: "production",
+		FS:          os.DirFS("testdata/builds/vite5"),
+	})
+	if err != nil {
+		t.Fatalf("glue did not initialize: %s", err)
+	}
+	ts, err := startTestServer(glue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(ts.URL + "/.vite/manifest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 404 {
+		t.Errorf("manifest served with status %d", resp.StatusCode)
+	}
+}
+
+func TestCompiledManifest(t *testing.T) {
+	fsys := os.DirFS("testdata/builds/vite6")
+	manifest, name, err := ReadManifest(fsys, ViteConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "dist/.vite/manifest.json" {
+		t.Errorf("manifest read from %s", name)
+	}
+
+	glue, err := NewVueGlue(&ViteConfig{
+		Environment: "production",
+		FS:          fsys,
+		Manifest:    manifest,
+		EntryPoint:  "src/main.ts",
+	})
+	if err != nil {
+		t.Fatalf("glue did not initialize: %s", err)
+	}
+	if glue.MainModule() != "assets/main-C7wZp0Qe.js" {
+		t.Errorf("main module %s", glue.MainModule())
+	}
+	tags, _ := glue.RenderTags()
+	precomputed, err := manifest.Tags("src/main.ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags != precomputed {
+		t.Errorf("Manifest.Tags does not match RenderTags:\n%s\n%s", precomputed, tags)
+	}
+	if _, err := manifest.Tags("src/views/About.vue"); !errors.Is(err, ErrUnknownEntry) {
+		t.Errorf("expected ErrUnknownEntry, got %v", err)
+	}
+	if err := glue.Reload(); !errors.Is(err, ErrManifestCompiled) {
+		t.Errorf("expected ErrManifestCompiled, got %v", err)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 type manifestNode struct {
@@ -35,61 +37,238 @@ func (n *manifestNode) subKey(key string) *manifestNode {
 	return nil
 }
 
+// Chunk is one entry in manifest.json.
+type Chunk struct {
+	// Key is the chunk's key in the manifest; for entries, the
+	// source path (src/main.ts), and for shared chunks, a name
+	// like _vendor.b43f27d7.js.
+	Key string `json:"-"`
+
+	File           string   `json:"file"`
+	Name           string   `json:"name,omitempty"`
+	Src            string   `json:"src,omitempty"`
+	IsEntry        bool     `json:"isEntry,omitempty"`
+	IsDynamicEntry bool     `json:"isDynamicEntry,omitempty"`
+	Imports        []string `json:"imports,omitempty"`
+	DynamicImports []string `json:"dynamicImports,omitempty"`
+	CSS            []string `json:"css,omitempty"`
+	Assets         []string `json:"assets,omitempty"`
+}
+
+// Manifest is the parsed chunk graph of manifest.json, keyed
+// the same way the file is.
+type Manifest map[string]*Chunk
+
+// stringAt returns the string value under key, or "".
+func (n *manifestNode) stringAt(key string) string {
+	leaf := n.subKey(key)
+	if leaf == nil || leaf.nodeType != reflect.String {
+		return ""
+	}
+	return leaf.value.String()
+}
+
+// boolAt returns the bool value under key, or false.
+func (n *manifestNode) boolAt(key string) bool {
+	leaf := n.subKey(key)
+	if leaf == nil || leaf.nodeType != reflect.Bool {
+		return false
+	}
+	return leaf.value.Bool()
+}
+
+// stringsAt returns the strings in the array under key.
+func (n *manifestNode) stringsAt(key string) []string {
+	leaf := n.subKey(key)
+	if leaf == nil {
+		return nil
+	}
+	var list []string
+	for _, child := range leaf.children {
+		if child.nodeType == reflect.String {
+			list = append(list, child.value.String())
+		}
+	}
+	return list
+}
+
+// chunkFromNode converts one top level manifest entry.
+func chunkFromNode(leaf *manifestNode) *Chunk {
+	chunk := &Chunk{
+		Key:            leaf.key,
+		File:           leaf.stringAt("file"),
+		Name:           leaf.stringAt("name"),
+		Src:            leaf.stringAt("src"),
+		Imports:        leaf.stringsAt("imports"),
+		DynamicImports: leaf.stringsAt("dynamicImports"),
+		CSS:            leaf.stringsAt("css"),
+		Assets:         leaf.stringsAt("assets"),
+	}
+	chunk.IsEntry = leaf.boolAt("isEntry")
+	chunk.IsDynamicEntry = leaf.boolAt("isDynamicEntry")
+	return chunk
+}
+
 // @see https://yourbasic.org/golang/json-example/
-func (m *manifestTarget) parseWithoutReflection(jsonData []byte) (*VueGlue, error) {
+//
+// entryPoint picks the main module when the manifest has
+// several entries; see Manifest.mainEntry.
+func (m *manifestTarget) parseWithoutReflection(jsonData []byte, entryPoint string) (*Snapshot, error) {
+	manifest, err := m.parseChunks(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	return snapshotFromManifest(manifest, entryPoint)
+}
+
+// snapshotFromManifest works out the main module and its
+// dependencies from a parsed manifest.
+func snapshotFromManifest(manifest Manifest, entryPoint string) (*Snapshot, error) {
+	snap := &Snapshot{
+		Manifest: manifest,
+	}
+
+	// Get entry point
+	entry, err := snap.Manifest.mainEntry(entryPoint)
+	if err != nil {
+		return nil, err
+	}
+	if entry.File == "" {
+		return nil, fmt.Errorf("%w: entry %s has no file", ErrManifestBadlyFormed, entry.Key)
+	}
+	snap.MainModule = entry.File
+
+	// imports are optional as of Vite 2.9
+	for _, key := range entry.Imports {
+		// these have a level of indirection for some reason
+		deref, ok := snap.Manifest[key]
+		if !ok {
+			return nil, ErrNoInputFile
+		}
+		if deref.File == "" {
+			return nil, ErrManifestBadlyFormed
+		}
+		snap.Imports = append(snap.Imports, deref.File)
+	}
+
+	// CSS is optional too
+	snap.CSSModule = entry.CSS
+
+	// and so are the plugin-legacy chunks
+	if legacy := snap.Manifest.LegacyEntry(entry.Key); legacy != nil {
+		if polyfills := snap.Manifest.LegacyPolyfills(); polyfills != nil {
+			snap.LegacyModule = legacy.File
+			snap.LegacyPolyfills = polyfills.File
+		}
+	}
+	if polyfills := snap.Manifest.ModernPolyfills(); polyfills != nil {
+		snap.ModernPolyfills = polyfills.File
+	}
+
+	return snap, nil
+}
+
+// parseChunks builds the chunk graph of a manifest. The
+// manifest must be a JSON object of objects; fields of the
+// wrong type are ignored.
+func (m *manifestTarget) parseChunks(jsonData []byte) (Manifest, error) {
 	var v interface{}
-	json.Unmarshal(jsonData, &v)
+	if err := json.Unmarshal(jsonData, &v); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrManifestBadlyFormed, err)
+	}
 	topNode := manifestNode{
 		key: "top",
 	}
 	m.Nodes = append(m.Nodes, &topNode)
 	m.siftCollections(&topNode, "", "", v)
+	if topNode.nodeType != reflect.Map {
+		return nil, fmt.Errorf("%w: not a JSON object", ErrManifestBadlyFormed)
+	}
 
-	// Get entry point
-	entry := (*manifestNode)(nil)
-	glue := &VueGlue{}
-
+	manifest := Manifest{}
 	for _, leaf := range topNode.children {
-		if leaf.subKey("isEntry") != nil {
-			entry = leaf
-			glue.MainModule = leaf.subKey("file").value.String()
-			break
+		if leaf.nodeType != reflect.Map {
+			return nil, fmt.Errorf("%w: %s is not an object", ErrManifestBadlyFormed, leaf.key)
 		}
+		manifest[leaf.key] = chunkFromNode(leaf)
 	}
-	if entry == nil {
-		return nil, ErrNoEntryPoint
-	}
+	return manifest, nil
+}
 
-	imports := entry.subKey("imports")
-	if imports == nil || len(imports.children) == 0 {
-		// return nil, errors.New("expected code to have js dependencies")
-		// turns out this will become optional as of Vite 2.9, so:
-	} else {
-		for _, child := range imports.children {
-			// these have a level of indirection for some reason
-			deref := topNode.subKey(child.value.String())
-			if deref == nil {
-				return nil, ErrNoInputFile
-			}
-			item := deref.subKey("file")
-			if item == nil {
-				return nil, ErrManifestBadlyFormed
-			}
-			glue.Imports = append(glue.Imports, item.value.String())
+// mainEntry finds the chunk for entryPoint. Without one, it
+// falls back to the manifest's only JS entry, or if there are
+// several, to index.html's entry; a build with no JS entries
+// falls back to its stylesheet entry in the same way. Legacy
+// entries and polyfills are never the main entry.
+func (m Manifest) mainEntry(entryPoint string) (*Chunk, error) {
+	if chunk, ok := m[entryPoint]; ok && chunk.IsEntry {
+		return chunk, nil
+	}
+	var scripts, stylesheets []*Chunk
+	for _, key := range m.Keys() {
+		chunk := m[key]
+		if !chunk.IsEntry || m.IsLegacy(key) || chunk.IsPolyfills() {
+			continue
+		}
+		if chunk.IsStylesheet() {
+			stylesheets = append(stylesheets, chunk)
+		} else {
+			scripts = append(scripts, chunk)
 		}
 	}
-
-	css := entry.subKey("css")
-	if css == nil || len(css.children) == 0 {
-		// not an error, since CSS is optional
-		return glue, nil
+	candidates := scripts
+	if len(candidates) == 0 {
+		candidates = stylesheets
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, ErrNoEntryPoint
+	case 1:
+		return candidates[0], nil
+	}
+	if chunk, ok := m["index.html"]; ok && chunk.IsEntry {
+		return chunk, nil
 	}
+	keys := make([]string, len(candidates))
+	for i, chunk := range candidates {
+		keys[i] = chunk.Key
+	}
+	return nil, fmt.Errorf("%w: %s", ErrAmbiguousEntryPoint, strings.Join(keys, ", "))
+}
 
-	for _, child := range css.children {
-		glue.CSSModule = append(glue.CSSModule, child.value.String())
+// Keys returns the manifest's keys in sorted order.
+func (m Manifest) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	return glue, nil
+// EntryFiles returns, for an entry chunk, the JS files it
+// statically imports (directly or not) and the CSS files of
+// the entry and those imports, in the order Vite's own HTML
+// would reference them.
+func (m Manifest) EntryFiles(key string) (imports []string, css []string) {
+	seen := map[string]bool{}
+	var walk func(key string, isEntry bool)
+	walk = func(key string, isEntry bool) {
+		chunk, ok := m[key]
+		if !ok || seen[key] {
+			return
+		}
+		seen[key] = true
+		for _, imported := range chunk.Imports {
+			walk(imported, false)
+		}
+		if !isEntry && chunk.File != "" {
+			imports = append(imports, chunk.File)
+		}
+		css = append(css, chunk.CSS...)
+	}
+	walk(key, true)
+	return imports, css
 }
 
 func (m *manifestTarget) siftCollections(leaf *manifestNode, indent, key string, v interface{}) {
@@ -133,6 +312,7 @@ func (m *manifestTarget) processInterface(leaf *manifestNode, indent, k string,
 	case map[string]interface{}:
 		m.siftCollections(leaf, indent+"    ", k, v)
 	default:
-		fmt.Printf("%s %s ?? %T (unknown)", indent, k, v)
+		// null: leave the node's type invalid, so the
+		// accessors skip it
 	}
 }
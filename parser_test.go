@@ -1,6 +1,7 @@
 package vueglue
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
@@ -23,8 +24,8 @@ func TestDevServer(t *testing.T) {
 		t.Fatalf("lib did not initialize: %s", err)
 	}
 	shouldContain := "main.ts"
-	if glue.MainModule != shouldContain {
-		t.Fatalf("dev tag looks wrong. expected %s, got %s", shouldContain, glue.MainModule)
+	if glue.MainModule() != shouldContain {
+		t.Fatalf("dev tag looks wrong. expected %s, got %s", shouldContain, glue.MainModule())
 	}
 
 	tags, err := glue.RenderTags()
@@ -48,9 +49,37 @@ func TestDevServer(t *testing.T) {
 	if err != nil {
 		t.Fatalf("non-default tags not rendered: %s", err)
 	}
-	shouldContain = glue.BaseURL + "/main.ts"
+	shouldContain = glue.BaseURL() + "/main.ts"
 	if !strings.Contains(string(tags), shouldContain) {
 		t.Fatalf("tags did not contain '%s'", shouldContain)
 	}
 
 }
+
+func TestMainEntry(t *testing.T) {
+	manifest := Manifest{
+		"src/admin.ts": {Key: "src/admin.ts", File: "assets/admin.js", IsEntry: true},
+		"src/main.ts":  {Key: "src/main.ts", File: "assets/main.js", IsEntry: true},
+		"src/About.vue": {
+			Key: "src/About.vue", File: "assets/About.js", IsDynamicEntry: true,
+		},
+	}
+	if _, err := manifest.mainEntry(""); !errors.Is(err, ErrAmbiguousEntryPoint) {
+		t.Errorf("expected ErrAmbiguousEntryPoint, got %v", err)
+	}
+	if chunk, err := manifest.mainEntry("src/admin.ts"); err != nil || chunk.File != "assets/admin.js" {
+		t.Errorf("configured entry not used: %v %v", chunk, err)
+	}
+
+	// An HTML entry is the app's page, so it is the main one.
+	manifest["index.html"] = &Chunk{Key: "index.html", File: "assets/index.js", IsEntry: true}
+	if chunk, err := manifest.mainEntry(""); err != nil || chunk.File != "assets/index.js" {
+		t.Errorf("index.html entry not used: %v %v", chunk, err)
+	}
+
+	delete(manifest, "index.html")
+	delete(manifest, "src/admin.ts")
+	if chunk, err := manifest.mainEntry(""); err != nil || chunk.File != "assets/main.js" {
+		t.Errorf("only entry not used: %v %v", chunk, err)
+	}
+}
@@ -0,0 +1,200 @@
+package vueglue
+
+import (
+	"html/template"
+	"sync"
+)
+
+// Platform describes a JS framework that Vite builds for: how to
+// recognise a project that uses it, where its entry point usually
+// lives, and any tags it needs in development.
+//
+// The built in platforms are vue, react, preact, svelte, solid,
+// lit, qwik and vanilla. Use RegisterPlatform to add your own, or
+// to replace one of these.
+type Platform struct {
+	// Name is the platform's name, as used for ViteConfig.Platform
+	// and JSAppParams.PackageType.
+	Name string
+
+	// Packages are the npm packages that mark a project as using
+	// this platform, in either dependencies or devDependencies.
+	// The version of the first one found is the platform's
+	// version. A platform without packages never matches.
+	Packages []string
+
+	// EntryPoint and EntryPointTS are the default entry points
+	// of a JS and a TypeScript project, relative to the JS
+	// project directory.
+	EntryPoint   string
+	EntryPointTS string
+
+	// DevPreamble is an html/template snippet rendered before the
+	// entry script in development, for frameworks whose hot
+	// reloading needs a runtime installed first. It is executed
+	// with .BaseURL (the dev server) and .Nonce (a CSP nonce,
+	// possibly empty) available.
+	DevPreamble string
+
+	// devTags is the compiled dev template for the platform, and
+	// preamble its DevPreamble alone (nil if it has none).
+	devTags  *template.Template
+	preamble *template.Template
+}
+
+// reactPreamble installs the React Fast Refresh runtime. This
+// is the same preamble @vitejs/plugin-react puts into Vite's
+// own index.html.
+const reactPreamble = `
+    <script type="module"` + nonceAttr + `>
+      import RefreshRuntime from "{{.BaseURL}}/@react-refresh"
+      RefreshRuntime.injectIntoGlobalHook(window)
+      window.$RefreshReg$ = () => {}
+      window.$RefreshSig$ = () => (type) => type
+      window.__vite_plugin_react_preamble_installed__ = true
+    </script>
+            `
+
+// The built in platforms, in the order they are checked.
+// The layouts follow the create-vite templates.
+var builtinPlatforms = []Platform{
+	{
+		Name:         "vue",
+		Packages:     []string{"vue"},
+		EntryPoint:   "src/main.js",
+		EntryPointTS: "src/main.ts",
+	},
+	{
+		Name:         "react",
+		Packages:     []string{"react"},
+		EntryPoint:   "src/main.jsx",
+		EntryPointTS: "src/main.tsx",
+		DevPreamble:  reactPreamble,
+	},
+	{
+		// preact refreshes through @prefresh/vite, which
+		// needs no preamble.
+		Name:         "preact",
+		Packages:     []string{"preact"},
+		EntryPoint:   "src/main.jsx",
+		EntryPointTS: "src/main.tsx",
+	},
+	{
+		// svelte (3, 4 or 5) only shows up in devDependencies.
+		Name:         "svelte",
+		Packages:     []string{"svelte"},
+		EntryPoint:   "src/main.js",
+		EntryPointTS: "src/main.ts",
+	},
+	{
+		Name:         "solid",
+		Packages:     []string{"solid-js"},
+		EntryPoint:   "src/index.jsx",
+		EntryPointTS: "src/index.tsx",
+	},
+	{
+		// lit apps are a custom element rather than an app
+		// mounted by a main script.
+		Name:         "lit",
+		Packages:     []string{"lit"},
+		EntryPoint:   "src/my-element.js",
+		EntryPointTS: "src/my-element.ts",
+	},
+	{
+		Name:         "qwik",
+		Packages:     []string{"@builder.io/qwik"},
+		EntryPoint:   "src/main.jsx",
+		EntryPointTS: "src/main.tsx",
+	},
+	{
+		// For some very odd reason, the vanilla JS project is
+		// flat, while the TS project puts its files in src/.
+		Name:         "vanilla",
+		EntryPoint:   "main.js",
+		EntryPointTS: "src/main.ts",
+	},
+}
+
+var (
+	platformsMu sync.RWMutex
+	platforms   []*Platform
+)
+
+func init() {
+	// Register backwards, so they are checked in list order.
+	for i := len(builtinPlatforms) - 1; i >= 0; i-- {
+		if err := RegisterPlatform(builtinPlatforms[i]); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterPlatform adds a platform, or replaces the platform of
+// the same name. Platforms registered later are checked first
+// when analyzing package.json, so a custom platform can claim a
+// project before the built in ones do.
+func RegisterPlatform(platform Platform) error {
+	if platform.Name == "" {
+		return ErrPlatformName
+	}
+
+	tmpl, err := template.New(platform.Name).Parse(platform.DevPreamble + devEntryTag)
+	if err != nil {
+		return err
+	}
+	platform.devTags = tmpl
+	if platform.DevPreamble != "" {
+		preamble, err := template.New(platform.Name + "-preamble").Parse(platform.DevPreamble)
+		if err != nil {
+			return err
+		}
+		platform.preamble = preamble
+	}
+
+	platformsMu.Lock()
+	defer platformsMu.Unlock()
+	kept := []*Platform{&platform}
+	for _, existing := range platforms {
+		if existing.Name != platform.Name {
+			kept = append(kept, existing)
+		}
+	}
+	platforms = kept
+	return nil
+}
+
+// LookupPlatform finds a registered platform by name.
+func LookupPlatform(name string) (Platform, bool) {
+	platformsMu.RLock()
+	defer platformsMu.RUnlock()
+	for _, platform := range platforms {
+		if platform.Name == name {
+			return *platform, true
+		}
+	}
+	return Platform{}, false
+}
+
+// detectPlatform returns the first platform (in check order)
+// whose packages appear in pkgJSON, and that package's version
+// string. The vanilla platform is returned if nothing matches.
+func detectPlatform(pkgJSON *PackageJSON) (Platform, string) {
+	platformsMu.RLock()
+	defer platformsMu.RUnlock()
+	for _, platform := range platforms {
+		for _, pkg := range platform.Packages {
+			if vers, ok := pkgJSON.Dependencies[pkg]; ok {
+				return *platform, vers
+			}
+			if vers, ok := pkgJSON.DevDependencies[pkg]; ok {
+				return *platform, vers
+			}
+		}
+	}
+	for _, platform := range platforms {
+		if platform.Name == "vanilla" {
+			return *platform, ""
+		}
+	}
+	return Platform{Name: "vanilla"}, ""
+}
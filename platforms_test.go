@@ -0,0 +1,107 @@
+package vueglue
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPlatformVersions(t *testing.T) {
+	params := analyzePackageJSON(&PackageJSON{
+		Dependencies:    map[string]string{"solid-js": "~1.8.15"},
+		DevDependencies: map[string]string{"vite": "^5.2.0"},
+	})
+	if params.PackageType != "solid" || params.SolidVersion != "1.8.15" || params.MajorVer != "1" {
+		t.Errorf("unexpected solid params: %+v", params)
+	}
+
+	params = analyzePackageJSON(&PackageJSON{
+		DevDependencies: map[string]string{"svelte": "^5.0.0-next.260", "vite": "^5.4.10"},
+	})
+	if params.PackageType != "svelte" || params.MajorVer != "5" || params.PlatformVer != "5.0.0" {
+		t.Errorf("unexpected svelte params: %+v", params)
+	}
+}
+
+// restorePlatforms puts the platform registry back the way it
+// is now when the test ends.
+func restorePlatforms(t *testing.T) {
+	platformsMu.RLock()
+	saved := append([]*Platform(nil), platforms...)
+	platformsMu.RUnlock()
+	t.Cleanup(func() {
+		platformsMu.Lock()
+		platforms = saved
+		platformsMu.Unlock()
+	})
+}
+
+func TestRegisterPlatform(t *testing.T) {
+	restorePlatforms(t)
+	if err := RegisterPlatform(Platform{}); err != ErrPlatformName {
+		t.Errorf("expected ErrPlatformName, got %v", err)
+	}
+	if err := RegisterPlatform(Platform{Name: "broken", DevPreamble: "{{ .Oops"}); err == nil {
+		t.Errorf("expected a bad preamble to be refused")
+	}
+
+	err := RegisterPlatform(Platform{
+		Name:         "marko",
+		Packages:     []string{"marko"},
+		EntryPoint:   "src/index.marko",
+		EntryPointTS: "src/index.marko",
+		DevPreamble:  `<script type="module"{{ with .Nonce }} nonce="{{ . }}"{{ end }} src="{{ .BaseURL }}/@marko/runtime"></script>`,
+	})
+	if err != nil {
+		t.Fatalf("platform did not register: %s", err)
+	}
+
+	// marko projects also depend on vue here, but the most
+	// recently registered platform is checked first.
+	params := analyzePackageJSON(&PackageJSON{
+		Dependencies:    map[string]string{"marko": "^5.32.0", "vue": "^3.4.0"},
+		DevDependencies: map[string]string{"vite": "^5.2.0"},
+	})
+	if params.PackageType != "marko" || params.EntryPoint != "src/index.marko" || params.PlatformVer != "5.32.0" {
+		t.Errorf("unexpected marko params: %+v", params)
+	}
+
+	platform, ok := LookupPlatform("marko")
+	if !ok || platform.EntryPoint != "src/index.marko" {
+		t.Errorf("could not look up platform: %+v", platform)
+	}
+
+	glue, err := initializeVueGlue(&ViteConfig{
+		Environment: "development",
+		FS:          os.DirFS("testdata"),
+		Platform:    "marko",
+		EntryPoint:  "src/index.marko",
+	})
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+	tags, err := glue.RenderTagsWithNonce("abc")
+	if err != nil {
+		t.Fatalf("tags did not render: %s", err)
+	}
+	for _, want := range []string{
+		`<script type="module" nonce="abc" src="http://localhost:5173/@marko/runtime"></script>`,
+		`<script type="module" src="http://localhost:5173/src/index.marko" nonce="abc"></script>`,
+	} {
+		if !strings.Contains(string(tags), want) {
+			t.Errorf("tags did not contain %q:\n%s", want, tags)
+		}
+	}
+}
+
+func TestRegisterPlatformRestored(t *testing.T) {
+	t.Run("register", func(t *testing.T) {
+		restorePlatforms(t)
+		if err := RegisterPlatform(Platform{Name: "marko"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if _, ok := LookupPlatform("marko"); ok {
+		t.Errorf("platform registered by a test was left behind")
+	}
+}
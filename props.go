@@ -0,0 +1,61 @@
+package vueglue
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+)
+
+// RenderProps serializes data as JSON into a script tag, so a
+// Go template can hand server data to the JS app that hydrates
+// or mounts on the page:
+//
+//	{{ $vue.RenderProps "app-props" .Props .Nonce }}
+//
+// renders as
+//
+//	<script type="application/json" id="app-props" nonce="...">{"user":"..."}</script>
+//
+// The tag is not executed by the browser, and the JSON is
+// escaped so that it cannot close the script element early
+// ("<", ">" and "&" become \u003c, \u003e and \u0026), so any
+// string data is safe to pass. Pass an empty nonce if you don't
+// use a Content Security Policy.
+//
+// On the client, read the props back with:
+//
+//	const el = document.getElementById('app-props');
+//	const props = el ? JSON.parse(el.textContent) : {};
+func (vg *VueGlue) RenderProps(id string, data interface{}, nonce string) (template.HTML, error) {
+	return PropsTag(id, data, nonce)
+}
+
+// PropsTag is RenderProps without a glue object.
+func PropsTag(id string, data interface{}, nonce string) (template.HTML, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	var tag bytes.Buffer
+	tag.WriteString(`<script type="application/json" id="`)
+	tag.WriteString(template.HTMLEscapeString(id))
+	tag.WriteString(`"`)
+	writeNonce(&tag, nonce)
+	tag.WriteString(`>`)
+	tag.Write(payload)
+	tag.WriteString(`</script>`)
+
+	return template.HTML(tag.String()), nil
+}
+
+// writeNonce adds a nonce attribute to a tag being built,
+// if there is a nonce.
+func writeNonce(tag *bytes.Buffer, nonce string) {
+	if nonce == "" {
+		return
+	}
+	tag.WriteString(` nonce="`)
+	tag.WriteString(template.HTMLEscapeString(nonce))
+	tag.WriteString(`"`)
+}
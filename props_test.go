@@ -0,0 +1,77 @@
+package vueglue
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestRenderProps(t *testing.T) {
+	glue := prodTestGlue(t)
+
+	data := map[string]interface{}{
+		"title": `</script><script>alert("x")</script>`,
+		"note":  "a & b <!-- c -->\u2028",
+		"count": 3,
+	}
+	tag, err := glue.RenderProps("app-props", data, `abc"123`)
+	if err != nil {
+		t.Fatalf("props did not render: %s", err)
+	}
+	out := string(tag)
+
+	prefix := `<script type="application/json" id="app-props" nonce="abc&#34;123">`
+	if !strings.HasPrefix(out, prefix) || !strings.HasSuffix(out, `</script>`) {
+		t.Fatalf("unexpected tag: %s", out)
+	}
+	body := strings.TrimSuffix(strings.TrimPrefix(out, prefix), `</script>`)
+	for _, bad := range []string{"<", ">", "&", "\u2028"} {
+		if strings.Contains(body, bad) {
+			t.Errorf("payload contains unescaped %q: %s", bad, body)
+		}
+	}
+
+	// The payload must still be the same data.
+	var back map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &back); err != nil {
+		t.Fatalf("payload is not JSON: %s", err)
+	}
+	if back["title"] != data["title"] || back["note"] != data["note"] {
+		t.Errorf("payload did not round trip: %v", back)
+	}
+
+	tag, err = PropsTag("plain", nil, "")
+	if err != nil {
+		t.Fatalf("props did not render: %s", err)
+	}
+	if string(tag) != `<script type="application/json" id="plain">null</script>` {
+		t.Errorf("unexpected tag: %s", tag)
+	}
+
+	if _, err := PropsTag("bad", make(chan int), ""); err == nil {
+		t.Errorf("expected an unmarshalable value to fail")
+	}
+}
+
+func TestRenderPropsInTemplate(t *testing.T) {
+	glue := prodTestGlue(t)
+	tmpl := template.Must(template.New("page").Parse(
+		`<body>{{ .Vue.RenderProps "app-props" .Props .Nonce }}</body>`,
+	))
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]interface{}{
+		"Vue":   glue,
+		"Props": map[string]string{"name": "<b>vite</b>"},
+		"Nonce": "n0nce",
+	})
+	if err != nil {
+		t.Fatalf("template failed: %s", err)
+	}
+	want := `<body><script type="application/json" id="app-props" nonce="n0nce">{"name":"\u003cb\u003evite\u003c/b\u003e"}</script></body>`
+	if buf.String() != want {
+		t.Errorf("got %s\nwant %s", buf.String(), want)
+	}
+}
@@ -1,6 +0,0 @@
-const runtime = require('react-refresh/runtime');
-runtime.injectIntoGlobalHook(window);
-window.$RefreshReg$ = () => { };
-window.$RefreshSig$ = () => type => type;
-window.__vite_plugin_react_preamble_installed__ = true;
-
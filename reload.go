@@ -0,0 +1,114 @@
+package vueglue
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"time"
+)
+
+// Reload re-reads manifest.json from DistFS and swaps in a
+// new Snapshot with the newly parsed entry point, imports and
+// CSS. Use it after deploying a new dist/ directory underneath
+// a running binary (this only makes sense with an os.DirFS; an
+// embed.FS never changes).
+//
+// If the new manifest cannot be read or parsed, or references
+// files that are not there yet, the previous state is kept and
+// the error is returned. Callers that need several manifest
+// values at once should take a Snapshot, which is guaranteed
+// to come from a single manifest.
+func (vg *VueGlue) Reload() error {
+	_, err := vg.reload(true)
+	return err
+}
+
+// reload does the work for Reload and Watch. Unless force is
+// set, nothing is swapped if the manifest on disk is the one
+// we already have. It reports whether a swap happened.
+func (vg *VueGlue) reload(force bool) (bool, error) {
+	if vg.environment != "production" {
+		return false, ErrNotProduction
+	}
+
+	if vg.manifestFile == "" {
+		return false, ErrManifestCompiled
+	}
+	contents, err := fs.ReadFile(vg.distFS, vg.manifestFile)
+	if err != nil {
+		return false, err
+	}
+
+	if !force && bytes.Equal(vg.state.Load().raw, contents) {
+		return false, nil
+	}
+
+	fresh, err := parseSnapshot(contents, vg.config.EntryPoint)
+	if err != nil {
+		return false, err
+	}
+	fresh.ssrManifest, err = loadSSRManifest(vg.distFS, vg.ssrManifestFile)
+	if err != nil {
+		return false, err
+	}
+	if err := vg.checkBuild(fresh); err != nil {
+		return false, err
+	}
+	if err := vg.setSnapshot(fresh); err != nil {
+		return false, err
+	}
+
+	vg.log().Info(
+		"reloaded manifest",
+		"manifest", vg.manifestFile,
+		"entry", fresh.MainModule,
+	)
+
+	return true, nil
+}
+
+// defaultWatchInterval is how often Watch polls when it is not
+// given an interval.
+const defaultWatchInterval = 2 * time.Second
+
+// Watch polls manifest.json every interval and reloads it
+// when its contents change. It blocks until ctx is cancelled,
+// so run it in its own goroutine:
+//
+//	go glue.Watch(ctx, 2*time.Second)
+//
+// An interval of zero or less (an unset config value, say)
+// polls every two seconds.
+//
+// Errors reading or parsing the manifest (say, in the middle
+// of a deploy) are logged, and the last good manifest stays in
+// use until the next successful poll.
+func (vg *VueGlue) Watch(ctx context.Context, interval time.Duration) error {
+	if vg.environment != "production" {
+		return ErrNotProduction
+	}
+	if vg.manifestFile == "" {
+		return ErrManifestCompiled
+	}
+
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := vg.reload(false); err != nil {
+				vg.log().Warn(
+					"could not reload manifest",
+					"manifest", vg.manifestFile,
+					"err", err,
+				)
+			}
+		}
+	}
+}
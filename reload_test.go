@@ -0,0 +1,193 @@
+package vueglue
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const reloadedManifest = `{
+  "src/main.ts": {
+    "file": "assets/main.1111aaaa.js",
+    "src": "src/main.ts",
+    "isEntry": true,
+    "css": ["assets/main.2222bbbb.css"]
+  }
+}`
+
+// prodGlueFromDir copies the test manifest into a scratch
+// dist directory and returns a production glue pointed at it.
+func prodGlueFromDir(t *testing.T) (*VueGlue, string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "dist"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	contents, err := os.ReadFile("testdata/manifest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := filepath.Join(dir, "dist", "manifest.json")
+	if err := os.WriteFile(manifest, contents, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	glue, err := NewVueGlue(&ViteConfig{
+		Environment: "production",
+		FS:          os.DirFS(dir),
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		// only the manifest is copied
+		SkipVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("could not create glue: %s", err)
+	}
+	return glue, manifest
+}
+
+func TestReload(t *testing.T) {
+	glue, manifest := prodGlueFromDir(t)
+
+	tags, err := glue.RenderTags()
+	if err != nil {
+		t.Fatalf("tags did not render: %s", err)
+	}
+	if !strings.Contains(string(tags), "assets/main.9e2e52ce.js") {
+		t.Fatalf("initial tags look wrong: %s", tags)
+	}
+
+	// A broken manifest leaves the old state in place.
+	if err := os.WriteFile(manifest, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := glue.Reload(); err == nil {
+		t.Fatalf("expected reload of empty manifest to fail")
+	}
+	tags, _ = glue.RenderTags()
+	if !strings.Contains(string(tags), "assets/main.9e2e52ce.js") {
+		t.Fatalf("failed reload changed tags: %s", tags)
+	}
+
+	if err := os.WriteFile(manifest, []byte(reloadedManifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := glue.Reload(); err != nil {
+		t.Fatalf("reload failed: %s", err)
+	}
+	tags, _ = glue.RenderTags()
+	for _, want := range []string{"assets/main.1111aaaa.js", "assets/main.2222bbbb.css"} {
+		if !strings.Contains(string(tags), want) {
+			t.Errorf("reloaded tags did not contain %s: %s", want, tags)
+		}
+	}
+	if strings.Contains(string(tags), "vendor.b43f27d7.js") {
+		t.Errorf("reloaded tags still reference old imports: %s", tags)
+	}
+}
+
+func TestReloadDevelopment(t *testing.T) {
+	glue, err := initializeVueGlue(nil)
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+	if err := glue.Reload(); err != ErrNotProduction {
+		t.Fatalf("expected ErrNotProduction, got %v", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	glue, manifest := prodGlueFromDir(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- glue.Watch(ctx, 10*time.Millisecond)
+	}()
+
+	if err := os.WriteFile(manifest, []byte(reloadedManifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		tags, err := glue.RenderTags()
+		if err != nil {
+			t.Fatalf("tags did not render: %s", err)
+		}
+		if strings.Contains(string(tags), "assets/main.1111aaaa.js") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("watcher never picked up the new manifest")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("expected watch to end with context.Canceled, got %v", err)
+	}
+}
+
+func TestWatchNoInterval(t *testing.T) {
+	glue, _ := prodGlueFromDir(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := glue.Watch(ctx, 0); err != context.DeadlineExceeded {
+		t.Fatalf("expected watch to end with context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestConcurrentReload(t *testing.T) {
+	glue, manifest := prodGlueFromDir(t)
+	original, err := os.ReadFile(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				snap := glue.Snapshot()
+				// Each snapshot must come from a single manifest.
+				oldEntry := snap.MainModule == "assets/main.9e2e52ce.js"
+				if oldEntry != (len(snap.Imports) == 1) {
+					t.Errorf("inconsistent snapshot: %+v", snap)
+					return
+				}
+				if _, err := glue.RenderTags(); err != nil {
+					t.Errorf("tags did not render: %s", err)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		contents := original
+		if i%2 == 0 {
+			contents = []byte(reloadedManifest)
+		}
+		if err := os.WriteFile(manifest, contents, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := glue.Reload(); err != nil {
+			t.Fatalf("reload failed: %s", err)
+		}
+	}
+	cancel()
+	wg.Wait()
+}
@@ -1,10 +1,14 @@
 package vueglue
 
 import (
+	"bytes"
 	"embed"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -29,12 +33,33 @@ func TestInitLib(t *testing.T) {
 		t.Fatalf("No glue was returned")
 	}
 
-	if glue.MainModule != "server.js" {
-		t.Fatalf("Expected main module to be %s, got %s", "server.ts", glue.MainModule)
+	if glue.MainModule() != "server.js" {
+		t.Fatalf("Expected main module to be %s, got %s", "server.ts", glue.MainModule())
 	}
 
 }
 
+func TestConfigNotModified(t *testing.T) {
+	config := &ViteConfig{
+		Environment: "development",
+		FS:          os.DirFS("testdata"),
+		EntryPoint:  "server.js",
+	}
+	glue, err := NewVueGlue(config)
+	if err != nil {
+		t.Fatalf("Library failed to initialize: %s", err)
+	}
+
+	if config.JSProjectPath != "" || config.DevServerPort != "" || config.DevDefaults != nil {
+		t.Fatalf("NewVueGlue modified its config: %+v", config)
+	}
+
+	resolved := glue.Config()
+	if resolved.JSProjectPath != "frontend" || resolved.DevServerPort != DEFAULT_PORT_V3 {
+		t.Fatalf("resolved config lacks defaults: %+v", resolved)
+	}
+}
+
 func TestServerHandler(t *testing.T) {
 	config := &ViteConfig{
 		Environment:   "development",
@@ -92,6 +117,7 @@ func TestFileVisibility(t *testing.T) {
 		{"subdir/", 404},
 		{"subdir/regfile.txt", 200},
 		{"subdir/.env-file", 404},
+		{"src/preamble.js", 404},
 	}
 
 	base := srv.URL
@@ -153,3 +179,47 @@ func TestEmbedAccess(t *testing.T) {
 	}
 
 }
+
+func TestLogRequests(t *testing.T) {
+	var buf bytes.Buffer
+	config := &ViteConfig{
+		Environment:   "development",
+		JSProjectPath: "testdata",
+		URLPrefix:     "/",
+		FS:            os.DirFS("testdata"),
+		EntryPoint:    "server.js",
+		Logger:        slog.New(slog.NewTextHandler(&buf, nil)),
+	}
+	glue, err := initializeVueGlue(config)
+	if err != nil {
+		t.Fatalf("no glue! %s", err)
+	}
+	handler, err := glue.FileServer()
+	if err != nil {
+		t.Fatalf("no handler was returned: %s", err)
+	}
+
+	// Without the middleware, nothing gets logged.
+	srv := httptest.NewServer(handler)
+	_, err = http.Head(srv.URL + "/regfile.txt")
+	srv.Close()
+	if err != nil {
+		t.Fatalf("could not ping server: %s", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no request logging, got %q", buf.String())
+	}
+
+	srv = httptest.NewServer(glue.LogRequests(handler))
+	defer srv.Close()
+	_, err = http.Head(srv.URL + "/not-there")
+	if err != nil {
+		t.Fatalf("could not ping server: %s", err)
+	}
+
+	for _, want := range []string{"path=/not-there", "status=404", "environment=development", "duration="} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log output %q did not contain %q", buf.String(), want)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package vueglue
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//go:embed ssr/sidecar.mjs
+var sidecarScript string
+
+// maxSidecarLine bounds a single response from the sidecar.
+var maxSidecarLine = 64 << 20
+
+// NodeRenderer is an SSRRenderer that keeps a long-lived Node
+// process running the server bundle from a Vite SSR build
+// (`vite build --ssr src/entry-server.js`). Requests and
+// responses are exchanged as lines of JSON over the process's
+// stdin and stdout, so any number of renders can be in flight
+// at once.
+//
+// The bundle must export a render(url, props) function that
+// returns an HTML string, or an object with html, head and
+// modules properties. The process is started on first use
+// (or by Start), and restarted if it dies.
+type NodeRenderer struct {
+	// Entry is the path of the server bundle, e.g.
+	// frontend/dist/server/entry-server.js.
+	Entry string
+
+	// Command overrides the command used to run the sidecar.
+	// Default is node, running the library's sidecar script.
+	Command []string
+
+	// Dir is the working directory of the sidecar.
+	// Default is the current directory.
+	Dir string
+
+	// Env is added to the sidecar's environment.
+	Env []string
+
+	// Logger receives the sidecar's stderr and lifecycle events.
+	// Default is slog.Default().
+	Logger *slog.Logger
+
+	mu     sync.Mutex
+	proc   *nodeProcess
+	nextID atomic.Uint64
+}
+
+// nodeProcess is one run of the sidecar.
+type nodeProcess struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[uint64]chan *nodeResponse
+
+	// logged is closed once stderr has been read to the end, and
+	// done once the process has exited.
+	logged chan struct{}
+	done   chan struct{}
+}
+
+type nodeRequest struct {
+	ID    uint64      `json:"id"`
+	URL   string      `json:"url"`
+	Props interface{} `json:"props"`
+}
+
+type nodeResponse struct {
+	ID    uint64 `json:"id"`
+	Error string `json:"error"`
+	SSROutput
+}
+
+func (nr *NodeRenderer) log() *slog.Logger {
+	if nr.Logger != nil {
+		return nr.Logger
+	}
+	return slog.Default()
+}
+
+// Start launches the sidecar if it is not already running.
+// Calling it is optional, but surfaces a bad configuration
+// at startup rather than on the first request.
+func (nr *NodeRenderer) Start() error {
+	_, err := nr.process()
+	return err
+}
+
+// process returns the running sidecar, starting one if needed.
+func (nr *NodeRenderer) process() (*nodeProcess, error) {
+	nr.mu.Lock()
+	defer nr.mu.Unlock()
+
+	if nr.proc != nil {
+		select {
+		case <-nr.proc.done:
+			nr.log().Warn("restarting SSR sidecar", "entry", nr.Entry)
+		default:
+			return nr.proc, nil
+		}
+	}
+
+	entry, err := filepath.Abs(nr.Entry)
+	if err != nil {
+		return nil, err
+	}
+
+	command := nr.Command
+	if len(command) == 0 {
+		command = []string{"node", "--input-type=module", "-e", sidecarScript}
+	}
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = nr.Dir
+	cmd.Env = append(os.Environ(), nr.Env...)
+	cmd.Env = append(cmd.Env, "VITE_GO_SSR_ENTRY="+entry)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	proc := &nodeProcess{
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: map[uint64]chan *nodeResponse{},
+		logged:  make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	logger := nr.log()
+	go func() {
+		defer close(proc.logged)
+		lines := bufio.NewScanner(stderr)
+		for lines.Scan() {
+			logger.Warn("SSR sidecar", "stderr", lines.Text())
+		}
+	}()
+	go proc.readResponses(stdout, logger)
+
+	nr.proc = proc
+	return proc, nil
+}
+
+// readResponses hands responses to their waiting requests
+// until the sidecar closes its stdout.
+func (p *nodeProcess) readResponses(stdout io.Reader, logger *slog.Logger) {
+	lines := bufio.NewScanner(stdout)
+	lines.Buffer(make([]byte, 64*1024), maxSidecarLine)
+	for lines.Scan() {
+		resp := &nodeResponse{}
+		if err := json.Unmarshal(lines.Bytes(), resp); err != nil {
+			logger.Error("bad response from SSR sidecar", "err", err)
+			continue
+		}
+		p.mu.Lock()
+		ch, ok := p.pending[resp.ID]
+		delete(p.pending, resp.ID)
+		p.mu.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+	if err := lines.Err(); err != nil {
+		// Nothing reads stdout any more, so the sidecar could
+		// block writing to it and never exit.
+		logger.Error("could not read from SSR sidecar", "err", err)
+		_ = p.cmd.Process.Kill()
+	}
+
+	// Wait closes the pipes, so stderr must be read out first.
+	<-p.logged
+	err := p.cmd.Wait()
+	logger.Warn("SSR sidecar exited", "err", err)
+
+	p.mu.Lock()
+	p.pending = nil
+	p.mu.Unlock()
+	close(p.done)
+}
+
+// Render implements SSRRenderer.
+func (nr *NodeRenderer) Render(ctx context.Context, url string, props interface{}) (*SSROutput, error) {
+	proc, err := nr.process()
+	if err != nil {
+		return nil, err
+	}
+
+	req := nodeRequest{
+		ID:    nr.nextID.Add(1),
+		URL:   url,
+		Props: props,
+	}
+	line, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	line = append(line, '\n')
+
+	ch := make(chan *nodeResponse, 1)
+	proc.mu.Lock()
+	if proc.pending == nil {
+		proc.mu.Unlock()
+		return nil, ErrSidecarExited
+	}
+	proc.pending[req.ID] = ch
+	proc.mu.Unlock()
+
+	proc.writeMu.Lock()
+	_, err = proc.stdin.Write(line)
+	proc.writeMu.Unlock()
+	if err != nil {
+		proc.forget(req.ID)
+		return nil, err
+	}
+
+	select {
+	case resp := <-ch:
+		return renderResult(url, resp)
+	case <-proc.done:
+		// The sidecar may have answered just before it exited;
+		// the response is delivered before done is closed.
+		select {
+		case resp := <-ch:
+			return renderResult(url, resp)
+		default:
+			return nil, ErrSidecarExited
+		}
+	case <-ctx.Done():
+		proc.forget(req.ID)
+		return nil, ctx.Err()
+	}
+}
+
+// renderResult turns a sidecar response into Render's results.
+func renderResult(url string, resp *nodeResponse) (*SSROutput, error) {
+	if resp.Error != "" {
+		return nil, fmt.Errorf("SSR render of %s failed: %s", url, resp.Error)
+	}
+	return &resp.SSROutput, nil
+}
+
+// forget drops a request we are no longer waiting on.
+func (p *nodeProcess) forget(id uint64) {
+	p.mu.Lock()
+	delete(p.pending, id)
+	p.mu.Unlock()
+}
+
+// Close shuts the sidecar down, giving it a few seconds to
+// finish any renders in flight.
+func (nr *NodeRenderer) Close() error {
+	nr.mu.Lock()
+	proc := nr.proc
+	nr.proc = nil
+	nr.mu.Unlock()
+
+	if proc == nil {
+		return nil
+	}
+
+	// The sidecar exits once its stdin is closed and its
+	// renders are done.
+	err := proc.stdin.Close()
+	select {
+	case <-proc.done:
+	case <-time.After(5 * time.Second):
+		_ = proc.cmd.Process.Kill()
+		<-proc.done
+	}
+	return err
+}
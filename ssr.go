@@ -0,0 +1,151 @@
+package vueglue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// SSROutput is what an SSRRenderer produces for a single page.
+type SSROutput struct {
+	// HTML is the rendered application markup.
+	HTML string `json:"html"`
+
+	// Head is any markup the app wants placed in <head>
+	// (teleported tags, titles and so on). Optional.
+	Head string `json:"head"`
+
+	// Modules are the source module ids used while rendering,
+	// as collected by the framework's SSR context (Vue's
+	// ctx.modules, for example). They are looked up in
+	// ssr-manifest.json to build preload tags.
+	Modules []string `json:"modules"`
+}
+
+// SSRRenderer renders a route of a Vite SSR build to HTML.
+// Implementations must be safe for concurrent use.
+type SSRRenderer interface {
+	Render(ctx context.Context, url string, props interface{}) (*SSROutput, error)
+}
+
+// SSRPage is a server rendered page, ready to be dropped into
+// a Go template:
+//
+//	<head>
+//	  {{ .Page.Head }}
+//	  {{ .Page.Preloads }}
+//	  {{ .Page.Tags }}
+//	</head>
+//	<body>
+//	  <div id="app">{{ .Page.HTML }}</div>
+//	</body>
+type SSRPage struct {
+	// HTML is the rendered app, to be hydrated on the client.
+	HTML template.HTML
+
+	// Head is the renderer's head markup.
+	Head template.HTML
+
+	// Preloads are the modulepreload and stylesheet links for
+	// the modules used during the render (production only).
+	Preloads template.HTML
+
+	// Tags are the client entry tags, as from RenderTags.
+	Tags template.HTML
+}
+
+// SSRManifest maps source module ids to the built files they
+// ended up in, as written by `vite build --ssrManifest`.
+type SSRManifest map[string][]string
+
+// ParseSSRManifest parses the contents of ssr-manifest.json.
+func ParseSSRManifest(contents []byte) (SSRManifest, error) {
+	manifest := SSRManifest{}
+	if err := json.Unmarshal(contents, &manifest); err != nil {
+		return nil, ErrManifestBadlyFormed
+	}
+	return manifest, nil
+}
+
+// PreloadTags renders the preload links for the files that
+// back the given modules, the same way Vite's SSR examples do.
+// Each file is linked only once.
+func (m SSRManifest) PreloadTags(modules []string) template.HTML {
+	seen := map[string]bool{}
+	var tags strings.Builder
+	for _, id := range modules {
+		for _, file := range m[id] {
+			if seen[file] {
+				continue
+			}
+			seen[file] = true
+			tags.WriteString(preloadLink(file))
+		}
+	}
+	return template.HTML(tags.String())
+}
+
+// preloadLink builds one link tag for a built file.
+func preloadLink(file string) string {
+	href := template.HTMLEscapeString(file)
+	switch path.Ext(file) {
+	case ".js", ".mjs":
+		return `<link rel="modulepreload" crossorigin href="` + href + `">` + "\n"
+	case ".css":
+		return `<link rel="stylesheet" href="` + href + `">` + "\n"
+	case ".woff":
+		return `<link rel="preload" href="` + href + `" as="font" type="font/woff" crossorigin>` + "\n"
+	case ".woff2":
+		return `<link rel="preload" href="` + href + `" as="font" type="font/woff2" crossorigin>` + "\n"
+	case ".gif":
+		return `<link rel="preload" href="` + href + `" as="image" type="image/gif">` + "\n"
+	case ".jpg", ".jpeg":
+		return `<link rel="preload" href="` + href + `" as="image" type="image/jpeg">` + "\n"
+	case ".png":
+		return `<link rel="preload" href="` + href + `" as="image" type="image/png">` + "\n"
+	}
+	return ""
+}
+
+// loadSSRManifest reads the SSR manifest if there is one.
+// A missing file is not an error: not every build does SSR.
+func loadSSRManifest(fsys fs.FS, file string) (SSRManifest, error) {
+	contents, err := fs.ReadFile(fsys, file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseSSRManifest(contents)
+}
+
+// Render server renders url with the configured SSRRenderer,
+// and returns the markup along with the preload and entry tags
+// the client needs to hydrate it. props are passed through to
+// the renderer as JSON.
+func (vg *VueGlue) Render(ctx context.Context, url string, props interface{}) (*SSRPage, error) {
+	if vg.ssr == nil {
+		return nil, ErrNoSSRRenderer
+	}
+
+	// Take the snapshot first, so the preloads and tags match
+	// even if a Reload happens while we are rendering.
+	snap := vg.state.Load()
+
+	out, err := vg.ssr.Render(ctx, url, props)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SSRPage{
+		HTML:     template.HTML(out.HTML),
+		Head:     template.HTML(out.Head),
+		Preloads: snap.ssrManifest.PreloadTags(out.Modules),
+		Tags:     snap.tags,
+	}, nil
+}
@@ -0,0 +1,69 @@
+// vite-go SSR sidecar.
+//
+// Loads a Vite SSR bundle ($VITE_GO_SSR_ENTRY) and answers render requests
+// read from stdin, one JSON object per line:
+//
+//   {"id": 1, "url": "/about", "props": {...}}
+//
+// Each response is written to stdout as a single line:
+//
+//   {"id": 1, "html": "...", "head": "...", "modules": ["src/App.vue"]}
+//
+// or {"id": 1, "error": "..."} if rendering failed. The bundle
+// must export render(url, props), returning either an HTML string
+// or an object with html, head and modules properties. When stdin
+// closes, the sidecar exits once the renders in flight are done.
+import { createInterface } from 'node:readline';
+import { pathToFileURL } from 'node:url';
+
+// stdout belongs to the protocol; send console output to stderr.
+console.log = console.info = console.debug = console.error;
+
+const reply = (msg) => process.stdout.write(JSON.stringify(msg) + '\n');
+
+const entryPath = process.env.VITE_GO_SSR_ENTRY;
+const entry = await import(pathToFileURL(entryPath).href);
+const render = entry.render ?? entry.default?.render;
+if (typeof render !== 'function') {
+  console.error(`vite-go: ${entryPath} does not export render()`);
+  process.exit(1);
+}
+
+let inFlight = 0;
+let closed = false;
+const exitIfDone = () => {
+  if (closed && inFlight === 0) {
+    // let the last responses drain before exiting
+    process.stdout.end(() => process.exit(0));
+  }
+};
+
+const lines = createInterface({ input: process.stdin });
+lines.on('line', async (line) => {
+  let req;
+  try {
+    req = JSON.parse(line);
+  } catch (e) {
+    console.error('vite-go: bad request', e);
+    return;
+  }
+  inFlight++;
+  try {
+    const out = await render(req.url, req.props);
+    const res = typeof out === 'string' ? { html: out } : out ?? {};
+    reply({
+      id: req.id,
+      html: res.html ?? '',
+      head: res.head ?? '',
+      modules: Array.from(res.modules ?? []),
+    });
+  } catch (e) {
+    reply({ id: req.id, error: String(e?.stack ?? e) });
+  }
+  inFlight--;
+  exitIfDone();
+});
+lines.on('close', () => {
+  closed = true;
+  exitIfDone();
+});
@@ -0,0 +1,309 @@
+package vueglue
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSSRManifestPreloads(t *testing.T) {
+	contents, err := os.ReadFile("testdata/ssr-manifest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := ParseSSRManifest(contents)
+	if err != nil {
+		t.Fatalf("ssr manifest did not parse: %s", err)
+	}
+
+	tags := string(manifest.PreloadTags([]string{
+		"src/App.vue",
+		"src/components/HelloWorld.vue",
+		"src/not-in-manifest.vue",
+	}))
+
+	for _, want := range []string{
+		`<link rel="modulepreload" crossorigin href="/assets/App.5d1e7b21.js">`,
+		`<link rel="stylesheet" href="/assets/App.c2c7bbf3.css">`,
+		`<link rel="preload" href="/assets/logo.03d6d6da.png" as="image" type="image/png">`,
+	} {
+		if !strings.Contains(tags, want) {
+			t.Errorf("preloads did not contain %q:\n%s", want, tags)
+		}
+	}
+	if n := strings.Count(tags, "App.5d1e7b21.js"); n != 1 {
+		t.Errorf("shared chunk linked %d times", n)
+	}
+	if strings.Contains(tags, "About") {
+		t.Errorf("preloads include an unused module:\n%s", tags)
+	}
+
+	if _, err := ParseSSRManifest([]byte(`{"src/App.vue": "nope"}`)); err == nil {
+		t.Errorf("expected a badly formed ssr manifest to fail")
+	}
+}
+
+// stubRenderer records what it was asked to render.
+type stubRenderer struct {
+	url   string
+	props interface{}
+}
+
+func (s *stubRenderer) Render(ctx context.Context, url string, props interface{}) (*SSROutput, error) {
+	s.url = url
+	s.props = props
+	return &SSROutput{
+		HTML:    `<div data-server-rendered="true">hi</div>`,
+		Head:    `<title>Hi</title>`,
+		Modules: []string{"src/App.vue"},
+	}, nil
+}
+
+func TestGlueRender(t *testing.T) {
+	glue, err := initializeVueGlue(nil)
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+	if _, err := glue.Render(context.Background(), "/", nil); !errors.Is(err, ErrNoSSRRenderer) {
+		t.Fatalf("expected ErrNoSSRRenderer, got %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "dist"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{"manifest.json", "ssr-manifest.json"} {
+		contents, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "dist", file), contents, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	renderer := &stubRenderer{}
+	glue, err = NewVueGlue(&ViteConfig{
+		Environment: "production",
+		FS:          os.DirFS(dir),
+		SSR:         renderer,
+		SkipVerify:  true,
+	})
+	if err != nil {
+		t.Fatalf("could not create glue: %s", err)
+	}
+
+	props := map[string]int{"count": 3}
+	page, err := glue.Render(context.Background(), "/about", props)
+	if err != nil {
+		t.Fatalf("render failed: %s", err)
+	}
+	if renderer.url != "/about" || renderer.props == nil {
+		t.Errorf("renderer got url %q, props %v", renderer.url, renderer.props)
+	}
+	if page.HTML != `<div data-server-rendered="true">hi</div>` || page.Head != `<title>Hi</title>` {
+		t.Errorf("unexpected page: %+v", page)
+	}
+	if !strings.Contains(string(page.Preloads), "/assets/App.c2c7bbf3.css") {
+		t.Errorf("preloads missing App css: %s", page.Preloads)
+	}
+	tags, _ := glue.RenderTags()
+	if page.Tags != tags {
+		t.Errorf("page tags differ from RenderTags")
+	}
+}
+
+// TestSSRSidecarHelper is not a real test: it stands in for the
+// Node sidecar when run as a subprocess by the tests below.
+func TestSSRSidecarHelper(t *testing.T) {
+	if os.Getenv("GO_WANT_SSR_SIDECAR") != "1" {
+		return
+	}
+	lines := bufio.NewScanner(os.Stdin)
+	out := json.NewEncoder(os.Stdout)
+	var mu sync.Mutex
+	for lines.Scan() {
+		var req nodeRequest
+		if err := json.Unmarshal(lines.Bytes(), &req); err != nil {
+			os.Exit(2)
+		}
+		go func(req nodeRequest) {
+			resp := nodeResponse{ID: req.ID}
+			switch req.URL {
+			case "/boom":
+				resp.Error = "render exploded"
+			case "/exit":
+				os.Exit(3)
+			case "/huge":
+				resp.HTML = strings.Repeat("x", 256<<10)
+			case "/slow":
+				time.Sleep(time.Second)
+				fallthrough
+			default:
+				props, _ := json.Marshal(req.Props)
+				resp.HTML = fmt.Sprintf("<p>%s %s</p>", req.URL, props)
+				resp.Modules = []string{"src/App.vue"}
+			}
+			mu.Lock()
+			_ = out.Encode(resp)
+			if req.URL == "/last" {
+				// answer, then exit straight away
+				os.Exit(0)
+			}
+			mu.Unlock()
+		}(req)
+	}
+	os.Exit(0)
+}
+
+func helperRenderer() *NodeRenderer {
+	return &NodeRenderer{
+		Entry:   "entry-server.js",
+		Command: []string{os.Args[0], "-test.run=^TestSSRSidecarHelper$"},
+		Env:     []string{"GO_WANT_SSR_SIDECAR=1"},
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func TestNodeRendererProtocol(t *testing.T) {
+	renderer := helperRenderer()
+	defer renderer.Close()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url := fmt.Sprintf("/page/%d", i)
+			out, err := renderer.Render(ctx, url, map[string]int{"n": i})
+			if err != nil {
+				t.Errorf("%s: render failed: %s", url, err)
+				return
+			}
+			want := fmt.Sprintf(`<p>%s {"n":%d}</p>`, url, i)
+			if out.HTML != want {
+				t.Errorf("%s: got %q, want %q", url, out.HTML, want)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if _, err := renderer.Render(ctx, "/boom", nil); err == nil || !strings.Contains(err.Error(), "render exploded") {
+		t.Errorf("expected render error, got %v", err)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := renderer.Render(timeout, "/slow", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	// A crashed sidecar fails its requests, and is restarted.
+	if _, err := renderer.Render(ctx, "/exit", nil); !errors.Is(err, ErrSidecarExited) {
+		t.Errorf("expected ErrSidecarExited, got %v", err)
+	}
+	if _, err := renderer.Render(ctx, "/again", nil); err != nil {
+		t.Errorf("sidecar did not restart: %s", err)
+	}
+
+	// A sidecar that exits right after answering still answered.
+	for i := 0; i < 3; i++ {
+		if _, err := renderer.Render(ctx, "/last", nil); err != nil {
+			t.Fatalf("answer before exit was lost: %s", err)
+		}
+		renderer.mu.Lock()
+		proc := renderer.proc
+		renderer.mu.Unlock()
+		<-proc.done
+	}
+}
+
+func TestNodeRendererLongResponse(t *testing.T) {
+	saved := maxSidecarLine
+	maxSidecarLine = 64 << 10
+	t.Cleanup(func() { maxSidecarLine = saved })
+
+	renderer := helperRenderer()
+	defer renderer.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The sidecar is killed rather than left blocked on stdout.
+	if _, err := renderer.Render(ctx, "/huge", nil); !errors.Is(err, ErrSidecarExited) {
+		t.Fatalf("expected ErrSidecarExited, got %v", err)
+	}
+	if _, err := renderer.Render(ctx, "/again", nil); err != nil {
+		t.Errorf("sidecar did not restart: %s", err)
+	}
+}
+
+func TestNodeRendererWithNode(t *testing.T) {
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is not installed")
+	}
+
+	entry := filepath.Join(t.TempDir(), "entry-server.mjs")
+	bundle := `
+export async function render(url, props) {
+  console.log("rendering", url);
+  if (url === "/slow") {
+    await new Promise((resolve) => setTimeout(resolve, 500));
+  }
+  return {
+    html: "<h1>" + url + " " + props.name + "</h1>",
+    modules: new Set(["src/App.vue"]),
+  };
+}
+`
+	if err := os.WriteFile(entry, []byte(bundle), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	renderer := &NodeRenderer{
+		Entry:  entry,
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	defer renderer.Close()
+	if err := renderer.Start(); err != nil {
+		t.Fatalf("sidecar did not start: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	out, err := renderer.Render(ctx, "/hello", map[string]string{"name": "vite"})
+	if err != nil {
+		t.Fatalf("render failed: %s", err)
+	}
+	if out.HTML != "<h1>/hello vite</h1>" {
+		t.Errorf("unexpected html %q", out.HTML)
+	}
+	if len(out.Modules) != 1 || out.Modules[0] != "src/App.vue" {
+		t.Errorf("unexpected modules %v", out.Modules)
+	}
+
+	// Closing lets a render in flight finish.
+	result := make(chan error, 1)
+	go func() {
+		_, err := renderer.Render(ctx, "/slow", map[string]string{"name": "vite"})
+		result <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	if err := renderer.Close(); err != nil {
+		t.Errorf("close failed: %s", err)
+	}
+	if err := <-result; err != nil {
+		t.Errorf("render in flight failed: %s", err)
+	}
+}
@@ -2,42 +2,211 @@ package vueglue
 
 import (
 	"bytes"
+	"fmt"
 	"html/template"
 )
 
-// RenderTags genarates the HTML tags that link a rendered
-// Go template with any Vue assets that need to be loaded.
-func (vg *VueGlue) RenderTags() (template.HTML, error) {
-	var tags string
-
-	if vg.Environment == "development" {
-		if vg.Platform == "react" {
-			// react requires some extra help to load
-			tags += `
-    <script src="/src/preamble.js"></script>
-            `
-		}
-		tags += `
-    <script type="module" src="{{.BaseURL}}/{{ .MainModule }}"></script>
+// nonceAttr adds a CSP nonce to a tag, if there is one;
+// rangeNonceAttr does the same inside a range.
+const (
+	nonceAttr      = `{{ with .Nonce }} nonce="{{ . }}"{{ end }}`
+	rangeNonceAttr = `{{ with $.Nonce }} nonce="{{ . }}"{{ end }}`
+)
+
+const devEntryTag = `
+    {{ if .Stylesheet }}
+    <link rel="stylesheet" href="{{.BaseURL}}/{{ .MainModule }}">
+    {{ else }}
+    <script type="module" src="{{.BaseURL}}/{{ .MainModule }}"` + nonceAttr + `></script>
+    {{ end }}
         `
 
-	} else {
-		tags += `
-	<script type="module" crossorigin src="/{{ .MainModule }}"></script>
+// The production tags are built from these pieces.
+const (
+	prodPolyfillTag = `
+	{{ if .ModernPolyfills }}
+	<script type="module" crossorigin src="/{{ .ModernPolyfills }}"` + nonceAttr + `></script>
+	{{ end }}`
+
+	prodEntryTag = `
+	{{ if .Stylesheet }}
+	<link rel="stylesheet" href="/{{ .MainModule }}">
+	{{ else }}
+	<script type="module" crossorigin src="/{{ .MainModule }}"` + nonceAttr + `></script>
+	{{ end }}`
+
+	prodPreloadTags = `
 	{{ range .Imports }}
-	<link rel="modulepreload" href="/{{.}}">
-	{{ end }}
+	<link rel="modulepreload" href="/{{.}}"` + rangeNonceAttr + `>
+	{{ end }}`
+
+	prodCSSTags = `
 	{{ range .CSSModule }}
-	<link rel="stylesheet" href="/{{.}}">
-	{{ end }}
+	<link rel="stylesheet" href="/{{.}}"` + rangeNonceAttr + `>
+	{{ end }}`
+
+	prodLinkTags = prodPreloadTags + prodCSSTags
+
+	prodLegacyTags = `
+	{{ if .LegacyModule }}` + legacyTags + `{{ end }}
 	`
+)
+
+// The tag templates are compiled once, when the package loads.
+// prodScripts is prodTags without the preload and CSS links, and
+// the link templates are those links alone.
+var (
+	devTags = template.Must(template.New("dev").Parse(devEntryTag))
+
+	prodTags = template.Must(template.New("prod").Parse(
+		prodPolyfillTag + prodEntryTag + prodLinkTags + prodLegacyTags))
+
+	prodScripts = template.Must(template.New("prod-scripts").Parse(
+		prodPolyfillTag + prodEntryTag + prodLegacyTags))
+
+	linkTags    = template.Must(template.New("links").Parse(prodLinkTags))
+	preloadTags = template.Must(template.New("preloads").Parse(prodPreloadTags))
+	cssTags     = template.Must(template.New("css").Parse(prodCSSTags))
+)
+
+// tagData is what the tag templates are executed against.
+type tagData struct {
+	BaseURL    string
+	MainModule string
+	Imports    []string
+	CSSModule  []string
+	Nonce      string
+
+	// Stylesheet is set when the main module is a CSS entry.
+	Stylesheet bool
+
+	LegacyModule    string
+	LegacyPolyfills string
+	ModernPolyfills string
+}
+
+// RenderTags genarates the HTML tags that link a rendered
+// Go template with any Vue assets that need to be loaded.
+//
+// The tags are rendered once per manifest snapshot, so this
+// is cheap to call on every page render, and does not allocate.
+func (vg *VueGlue) RenderTags() (template.HTML, error) {
+	return vg.state.Load().tags, nil
+}
+
+// RenderTagsWithNonce is RenderTags for pages served with a
+// Content Security Policy: each script and link tag gets the
+// nonce.
+// Since the nonce changes with every request, these tags are
+// rendered on each call.
+//
+//	{{ $vue.RenderTagsWithNonce .Nonce }}
+func (vg *VueGlue) RenderTagsWithNonce(nonce string) (template.HTML, error) {
+	if nonce == "" {
+		return vg.RenderTags()
+	}
+	return vg.renderTags(vg.state.Load(), nonce)
+}
+
+// RenderScripts renders just the entry's script tags: what
+// RenderTags renders, less the platform's dev preamble and the
+// preload and CSS links. Use it with RenderPreamble when a page
+// puts those elsewhere, say the links in <head> and the scripts
+// at the end of <body>.
+func (vg *VueGlue) RenderScripts(nonce string) (template.HTML, error) {
+	tmpl := prodScripts
+	if vg.environment == "development" {
+		tmpl = devTags
+	}
+	return vg.executeTags(tmpl, vg.state.Load(), nonce)
+}
+
+// RenderLinks renders the links RenderScripts leaves out: a
+// modulepreload link for each chunk the entry imports, and a
+// stylesheet link for each of its CSS files. In development Vite
+// loads both itself, so there are none.
+func (vg *VueGlue) RenderLinks(nonce string) (template.HTML, error) {
+	return vg.renderLinks(linkTags, nonce)
+}
+
+// RenderPreloads renders just the modulepreload links of
+// RenderLinks.
+func (vg *VueGlue) RenderPreloads(nonce string) (template.HTML, error) {
+	return vg.renderLinks(preloadTags, nonce)
+}
+
+// RenderCSS renders just the stylesheet links of RenderLinks.
+func (vg *VueGlue) RenderCSS(nonce string) (template.HTML, error) {
+	return vg.renderLinks(cssTags, nonce)
+}
+
+// renderLinks runs one of the link templates in production.
+func (vg *VueGlue) renderLinks(tmpl *template.Template, nonce string) (template.HTML, error) {
+	if vg.environment == "development" {
+		return "", nil
+	}
+	return vg.executeTags(tmpl, vg.state.Load(), nonce)
+}
+
+// RenderPreamble renders the platform's dev preamble (see
+// Platform.DevPreamble), which must come before the entry script.
+// It is empty in production, and for platforms without one.
+func (vg *VueGlue) RenderPreamble(nonce string) (template.HTML, error) {
+	if vg.environment != "development" {
+		return "", nil
 	}
-	tmpl, err := template.New("tags").Parse(tags)
+	platform, ok := LookupPlatform(vg.platform)
+	if !ok || platform.preamble == nil {
+		return "", nil
+	}
+	return vg.executeTags(platform.preamble, vg.state.Load(), nonce)
+}
+
+// Tags renders the production tags for an entry of the
+// manifest, as RenderTags would if it were the main entry.
+func (m Manifest) Tags(key string) (template.HTML, error) {
+	chunk, ok := m[key]
+	if !ok || !chunk.IsEntry {
+		return "", fmt.Errorf("%w: %s", ErrUnknownEntry, key)
+	}
+	snap, err := snapshotFromManifest(m, key)
 	if err != nil {
 		return "", err
 	}
+	glue := &VueGlue{environment: "production"}
+	return glue.renderTags(snap, "")
+}
+
+// renderTags builds the tags for a snapshot.
+func (vg *VueGlue) renderTags(snap *Snapshot, nonce string) (template.HTML, error) {
+	tmpl := prodTags
+	if vg.environment == "development" {
+		tmpl = devTags
+		if platform, ok := LookupPlatform(vg.platform); ok {
+			tmpl = platform.devTags
+		}
+	}
+	return vg.executeTags(tmpl, snap, nonce)
+}
+
+// executeTags runs one of the tag templates for a snapshot.
+func (vg *VueGlue) executeTags(tmpl *template.Template, snap *Snapshot, nonce string) (template.HTML, error) {
+	data := tagData{
+		BaseURL:    vg.baseURL,
+		MainModule: snap.MainModule,
+		Imports:    snap.Imports,
+		CSSModule:  snap.CSSModule,
+		Nonce:      nonce,
+		Stylesheet: isStylesheet(snap.MainModule),
+
+		LegacyModule:    snap.LegacyModule,
+		LegacyPolyfills: snap.LegacyPolyfills,
+		ModernPolyfills: snap.ModernPolyfills,
+	}
 	var buffer bytes.Buffer
-	tmpl.Execute(&buffer, vg)
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", err
+	}
 
 	return template.HTML(buffer.String()), nil
 }
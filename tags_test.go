@@ -0,0 +1,199 @@
+package vueglue
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func prodTestGlue(tb testing.TB) *VueGlue {
+	tb.Helper()
+	contents, err := os.ReadFile("testdata/manifest.json")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	glue, err := ParseManifest(contents)
+	if err != nil {
+		tb.Fatalf("manifest did not parse: %s", err)
+	}
+	return glue
+}
+
+func TestProductionTags(t *testing.T) {
+	glue := prodTestGlue(t)
+
+	tags, err := glue.RenderTags()
+	if err != nil {
+		t.Fatalf("tags did not render: %s", err)
+	}
+	for _, want := range []string{
+		`<script type="module" crossorigin src="/assets/main.9e2e52ce.js"></script>`,
+		`<link rel="modulepreload" href="/assets/vendor.b43f27d7.js">`,
+		`<link rel="stylesheet" href="/assets/main.0f2a382e.css">`,
+	} {
+		if !strings.Contains(string(tags), want) {
+			t.Errorf("tags did not contain %q", want)
+		}
+	}
+}
+
+func TestRenderTagsAllocs(t *testing.T) {
+	prod := prodTestGlue(t)
+	dev, err := initializeVueGlue(nil)
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+
+	for name, glue := range map[string]*VueGlue{"production": prod, "development": dev} {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = glue.RenderTags()
+		})
+		if allocs != 0 {
+			t.Errorf("%s: RenderTags allocated %v times per call", name, allocs)
+		}
+	}
+}
+
+func BenchmarkRenderTags(b *testing.B) {
+	glue := prodTestGlue(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = glue.RenderTags()
+	}
+}
+
+func BenchmarkRenderTagsParallel(b *testing.B) {
+	glue := prodTestGlue(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = glue.RenderTags()
+		}
+	})
+}
+
+// BenchmarkBuildSnapshot covers the work done once per manifest
+// load: parsing plus rendering the tags.
+func BenchmarkBuildSnapshot(b *testing.B) {
+	glue := prodTestGlue(b)
+	contents := glue.state.Load().raw
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		snap, err := parseSnapshot(contents, "")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := glue.renderTags(snap, ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestReactPreamble(t *testing.T) {
+	config := &ViteConfig{
+		Environment: "development",
+		FS:          os.DirFS("testdata"),
+		EntryPoint:  "src/main.jsx",
+		Platform:    "react",
+	}
+	glue, err := initializeVueGlue(config)
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+
+	tags, err := glue.RenderTagsWithNonce("r4nd0m")
+	if err != nil {
+		t.Fatalf("tags did not render: %s", err)
+	}
+	out := string(tags)
+	for _, want := range []string{
+		`<script type="module" nonce="r4nd0m">`,
+		`/@react-refresh"`,
+		`RefreshRuntime.injectIntoGlobalHook(window)`,
+		`window.__vite_plugin_react_preamble_installed__ = true`,
+		`<script type="module" src="http://localhost:5173/src/main.jsx" nonce="r4nd0m"></script>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("tags did not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "preamble.js") {
+		t.Errorf("tags still load preamble.js:\n%s", out)
+	}
+
+	// The cached tags have no nonce at all.
+	tags, _ = glue.RenderTags()
+	if strings.Contains(string(tags), "nonce") {
+		t.Errorf("cached tags have a nonce:\n%s", tags)
+	}
+}
+
+func TestRenderScripts(t *testing.T) {
+	glue := prodTestGlue(t)
+
+	scripts, err := glue.RenderScripts("n0nce")
+	if err != nil {
+		t.Fatalf("scripts did not render: %s", err)
+	}
+	out := string(scripts)
+	if !strings.Contains(out, `<script type="module" crossorigin src="/assets/main.9e2e52ce.js" nonce="n0nce"></script>`) {
+		t.Errorf("no entry script:\n%s", out)
+	}
+	if strings.Contains(out, "<link") {
+		t.Errorf("scripts have links:\n%s", out)
+	}
+	links, err := glue.RenderLinks("n0nce")
+	if err != nil {
+		t.Fatalf("links did not render: %s", err)
+	}
+	for _, want := range []string{
+		`<link rel="modulepreload" href="/assets/vendor.b43f27d7.js" nonce="n0nce">`,
+		`<link rel="stylesheet" href="/assets/main.0f2a382e.css" nonce="n0nce">`,
+	} {
+		if !strings.Contains(string(links), want) {
+			t.Errorf("links did not contain %q:\n%s", want, links)
+		}
+	}
+	if strings.Contains(string(links), "<script") {
+		t.Errorf("links have scripts:\n%s", links)
+	}
+	preloads, _ := glue.RenderPreloads("")
+	css, _ := glue.RenderCSS("")
+	if !strings.Contains(string(preloads), "modulepreload") || strings.Contains(string(preloads), "stylesheet") {
+		t.Errorf("unexpected preloads:\n%s", preloads)
+	}
+	if !strings.Contains(string(css), "stylesheet") || strings.Contains(string(css), "modulepreload") {
+		t.Errorf("unexpected css:\n%s", css)
+	}
+	if preamble, _ := glue.RenderPreamble("n0nce"); preamble != "" {
+		t.Errorf("production preamble: %s", preamble)
+	}
+
+	config := &ViteConfig{
+		Environment: "development",
+		FS:          os.DirFS("testdata"),
+		EntryPoint:  "src/main.jsx",
+		Platform:    "react",
+	}
+	dev, err := initializeVueGlue(config)
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+	scripts, _ = dev.RenderScripts("")
+	if strings.Contains(string(scripts), "@react-refresh") || !strings.Contains(string(scripts), "/src/main.jsx") {
+		t.Errorf("unexpected dev scripts:\n%s", scripts)
+	}
+	if links, _ := dev.RenderLinks(""); links != "" {
+		t.Errorf("development links: %s", links)
+	}
+	preamble, err := dev.RenderPreamble("n0nce")
+	if err != nil {
+		t.Fatalf("preamble did not render: %s", err)
+	}
+	if !strings.Contains(string(preamble), `<script type="module" nonce="n0nce">`) || strings.Contains(string(preamble), "main.jsx") {
+		t.Errorf("unexpected preamble:\n%s", preamble)
+	}
+}
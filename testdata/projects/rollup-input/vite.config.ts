@@ -0,0 +1,19 @@
+import { resolve } from 'path'
+import { defineConfig } from 'vite'
+import vue from '@vitejs/plugin-vue'
+
+export default defineConfig({
+  plugins: [vue()],
+  build: {
+    manifest: true,
+    rollupOptions: {
+      input: {
+        'shop': resolve(__dirname, 'src/shop/entry.js'),
+        admin: resolve(__dirname, 'admin/index.html'),
+      },
+      output: {
+        manualChunks: { vendor: ['vue'] },
+      },
+    },
+  },
+})
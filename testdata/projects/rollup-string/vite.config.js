@@ -0,0 +1,5 @@
+export default {
+  build: {
+    rollupOptions: { input: "./src/entry-client.js" },
+  },
+}
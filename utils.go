@@ -1,11 +1,9 @@
 package vueglue
 
 import (
-	"embed"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/fs"
 	"regexp"
 )
 
@@ -19,28 +17,29 @@ type PackageJSON struct {
 }
 
 type JSAppParams struct {
-	JSHash        string `json:"hash"`
-	ViteVersion   string `json:"vite_version"`
-	ViteMajorVer  string `json:"vite_major_version"`
-	PackageType   string `json:"package_type"`
-	MajorVer      string `json:"major_version,omitempty"`
-	EntryPoint    string `json:"entry_point"`
-	HasTypeScript bool   `json:"has_ts"`
-	IsVanilla     bool   `json:"is_vanilla,omitempty"`
-	VueVersion    string `json:"vue_version,omitempty"`
-	ReactVersion  string `json:"react_version,omitempty"`
-	PreactVersion string `json:"preact_version,omitempty"`
-	SvelteVersion string `json:"svelte_version,omitempty"`
-	LitVersion    string `json:"lit_version,omitempty"`
+	JSHash        string   `json:"hash"`
+	ViteVersion   string   `json:"vite_version"`
+	ViteMajorVer  string   `json:"vite_major_version"`
+	PackageType   string   `json:"package_type"`
+	MajorVer      string   `json:"major_version,omitempty"`
+	PlatformVer   string   `json:"platform_version,omitempty"`
+	EntryPoint    string   `json:"entry_point"`
+	EntryPoints   []string `json:"entry_points,omitempty"`
+	EntrySource   string   `json:"entry_source"`
+	HasTypeScript bool     `json:"has_ts"`
+	IsVanilla     bool     `json:"is_vanilla,omitempty"`
+	VueVersion    string   `json:"vue_version,omitempty"`
+	ReactVersion  string   `json:"react_version,omitempty"`
+	PreactVersion string   `json:"preact_version,omitempty"`
+	SvelteVersion string   `json:"svelte_version,omitempty"`
+	LitVersion    string   `json:"lit_version,omitempty"`
+	SolidVersion  string   `json:"solid_version,omitempty"`
+	QwikVersion   string   `json:"qwik_version,omitempty"`
 }
 
 func (vc *ViteConfig) parsePackageJSON() (*PackageJSON, error) {
 	// If not set, try and find package.json
-	path := ""
-	if _, ok := vc.FS.(embed.FS); ok {
-		path = vc.JSProjectPath + "/"
-	}
-	buf, err := fs.ReadFile(vc.FS, path+"package.json")
+	buf, err := vc.readProjectFile("package.json")
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +53,13 @@ func (vc *ViteConfig) parsePackageJSON() (*PackageJSON, error) {
 	return &content, nil
 }
 
+// analyzePackageJSON works out what it can from package.json.
+// It returns nil if this is not a Vite project.
 func analyzePackageJSON(pkgJSON *PackageJSON) *JSAppParams {
-	semVer := regexp.MustCompile(`^[\^]*((\d+)\.\d+\.\d+)$`)
+	if pkgJSON == nil {
+		return nil
+	}
+	semVer := regexp.MustCompile(`^[\^~]*((\d+)\.\d+\.\d+)(-[0-9A-Za-z.-]+)?$`)
 
 	// parse for a ver; return the full version,
 	// and the major version. Empty strings if
@@ -89,133 +93,35 @@ func analyzePackageJSON(pkgJSON *PackageJSON) *JSAppParams {
 		output.HasTypeScript = true
 	}
 
-	supported := []string{
-		"vue",
-		"react",
-		"preact",
-		"svelte", // devdep!
-		"lit",    // won't really support
-	}
-
-	var vers string
-	for _, pkg := range supported {
-		if pkg == "svelte" {
-			// special cased because svelte does not put
-			// any configuration into dependencies.
-			if sVer, ok := pkgJSON.DevDependencies["svelte"]; ok {
-				vers = sVer
-				major, full := getSemVer(vers)
-				output.PackageType = pkg
-				output.MajorVer = major
-				output.SvelteVersion = full
-
-				entryPt := "src/main.js"
-				if output.HasTypeScript {
-					entryPt = "src/main.ts"
-				}
-				output.EntryPoint = entryPt
-				break
-			}
-		} else {
-			if vers, ok = pkgJSON.Dependencies[pkg]; ok {
-				output.PackageType = pkg
-				major, full := getSemVer(vers)
-				output.MajorVer = major
-
-				// handle by category
-				entryPt := "src/main.js" // most common case
-				switch pkg {
-				case "vue":
-					output.VueVersion = full
-					if output.HasTypeScript {
-						entryPt = "src/main.ts"
-					}
-				case "react":
-					output.ReactVersion = full
-					if output.HasTypeScript {
-						entryPt = "src/main.tsx"
-					} else {
-						entryPt = "src/main.jsx"
-					}
-				case "preact":
-					output.PreactVersion = full
-					if output.HasTypeScript {
-						entryPt = "src/main.tsx"
-					} else {
-						entryPt = "src/main.jsx"
-					}
-				case "lit":
-					output.LitVersion = full
-					// we do not set entryPt;
-					// lit is just too weird.
-					entryPt = ""
-				}
-				// We know as much as we can...
-				output.EntryPoint = entryPt
-				break
-			}
-		}
-
-	}
-
-	// If we do not have type, call it Vanilla
-	if output.PackageType == "" {
+	platform, vers := detectPlatform(pkgJSON)
+	major, full := getSemVer(vers)
+	output.PackageType = platform.Name
+	output.MajorVer = major
+	output.PlatformVer = full
+	output.EntryPoint = platform.EntryPoint
+	if output.HasTypeScript {
+		output.EntryPoint = platform.EntryPointTS
+	}
+
+	switch platform.Name {
+	case "vue":
+		output.VueVersion = full
+	case "react":
+		output.ReactVersion = full
+	case "preact":
+		output.PreactVersion = full
+	case "svelte":
+		output.SvelteVersion = full
+	case "lit":
+		output.LitVersion = full
+	case "solid":
+		output.SolidVersion = full
+	case "qwik":
+		output.QwikVersion = full
+	case "vanilla":
 		output.IsVanilla = true
-		output.PackageType = "vanilla"
-		// Vite choses entry points anyway. For some
-		// very odd reason, the JS project is flat,
-		// and the TS project puts files in src/
-		// Why? Good question.
-		if output.HasTypeScript {
-			output.EntryPoint = "src/main.ts"
-		} else {
-			output.EntryPoint = "main.js"
-		}
 	}
 
-	// ViteVer
-
-	// TS
-	// script "build": "tsc && vite build",
-	// OR: DD "typescript": "^4.6.4"
-
-	// Vanilla
-	// NO DD
-	// entry like Vue
-
-	// Vanilla TS
-	// script + DD
-
-	// VueVer
-	//  deps "vue": "^3.2.37"
-	// src/main.js, src/main.ts
-
-	// ReactVer
-	// deps "react": "^18.2.0"
-	// src/main.jsx
-
-	// React TS
-	// src/main.tsx
-
-	// Preact
-	// dep "preact": "^10.9.0"
-	// TS: script + DD
-	// entry as react
-
-	// SvelteVer
-	// devdeps: "svelte": "^3.49.0",
-	// entry: as Vue
-
-	// SvelteTSVer
-	// NO build step info
-	// DD: "typescript": "^4.6.4"
-
-	// Lit
-	// Weird structure!
-	// deps "lit": "^2.2.7"
-	// TS script + DD
-	// weird entry src/my-element.ts
-
 	return &output
 }
 
@@ -233,6 +139,12 @@ func (vc *ViteConfig) getViteVersion() (string, error) {
 
 }
 
+// SetDevelopmentDefaults fills in anything not already set, using
+// what it can learn from the JS project. The entry point comes from
+// build.rollupOptions.input in the Vite config if there is one,
+// then from the module scripts in index.html, and only then from
+// the platform's usual layout. Stylesheet inputs are only used
+// as the entry point if there is nothing else.
 func (vc *ViteConfig) SetDevelopmentDefaults() error {
 	// Make sure we can find package.json:
 	if vc.JSProjectPath == "" {
@@ -248,6 +160,12 @@ func (vc *ViteConfig) SetDevelopmentDefaults() error {
 	if defaults == nil {
 		return errors.New("invalid configuration")
 	}
+	defaults.EntrySource = EntrySourcePackageJSON
+	if entries, source := vc.detectEntryPoints(); len(entries) > 0 {
+		defaults.EntryPoint = firstScript(entries)
+		defaults.EntryPoints = entries
+		defaults.EntrySource = source
+	}
 	vc.DevDefaults = defaults
 	version, err := vc.getViteVersion()
 	if err != nil {
@@ -296,6 +214,13 @@ func (vc *ViteConfig) SetProductionDefaults() error {
 	if vc.URLPrefix == "" {
 		vc.URLPrefix = "/assets/"
 	}
+	if vc.EntryPoint == "" && vc.FS != nil {
+		// If the JS sources are in the FS, they tell us which of
+		// the manifest's entries is the main one.
+		if entries, _ := vc.detectEntryPoints(); len(entries) > 0 {
+			vc.EntryPoint = firstScript(entries)
+		}
+	}
 
 	return nil
 }
@@ -18,11 +18,19 @@ func TestDefaultParams(t *testing.T) {
 		typescript bool
 		entryPt    string
 	}{
+		{"package-lit.json", "lit", "5", "5173", false, "src/my-element.js"},
+		{"package-lit-ts.json", "lit", "5", "5173", true, "src/my-element.ts"},
+		{"package-preact.json", "preact", "5", "5173", false, "src/main.jsx"},
 		{"package-preact-ts.json", "preact", "3", "5173", true, "src/main.tsx"},
+		{"package-qwik-ts.json", "qwik", "5", "5173", true, "src/main.tsx"},
 		{"package-react-ts.json", "react", "3", "5173", true, "src/main.tsx"},
 		{"package-react.json", "react", "3", "5173", false, "src/main.jsx"},
 		{"package-svelte-ts.json", "svelte", "3", "5173", true, "src/main.ts"},
+		{"package-solid.json", "solid", "5", "5173", false, "src/index.jsx"},
+		{"package-solid-ts.json", "solid", "5", "5173", true, "src/index.tsx"},
 		{"package-svelte.json", "svelte", "3", "5173", false, "src/main.js"},
+		{"package-svelte4-ts.json", "svelte", "5", "5173", true, "src/main.ts"},
+		{"package-svelte5.json", "svelte", "5", "5173", false, "src/main.js"},
 		{"package-vanilla-ts.json", "vanilla", "3", "5173", true, "src/main.ts"},
 		{"package-vanilla.json", "vanilla", "3", "5173", false, "main.js"},
 		{"package-vue-ts.json", "vue", "3", "5173", true, "src/main.ts"},
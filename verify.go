@@ -0,0 +1,198 @@
+package vueglue
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// cssURLRE finds the url() references in a built stylesheet.
+var cssURLRE = regexp.MustCompile(`url\(\s*['"]?([^'")]+?)['"]?\s*\)`)
+
+// VerifyError is returned by Verify when the dist directory does
+// not match the manifest.
+type VerifyError struct {
+	// Missing are files the manifest references that are not in
+	// DistFS, relative to the dist directory.
+	Missing []string
+
+	// Orphans are files in the directories the build writes to
+	// that the manifest does not reference. Source maps of files
+	// it does reference, and files its stylesheets load through
+	// url() (fonts, say), are not orphans.
+	Orphans []string
+}
+
+func (e *VerifyError) Error() string {
+	var msg strings.Builder
+	if len(e.Missing) > 0 {
+		fmt.Fprintf(&msg, "%d file(s) in the manifest are missing from the build: %s",
+			len(e.Missing), strings.Join(e.Missing, ", "))
+	}
+	if len(e.Orphans) > 0 {
+		if msg.Len() > 0 {
+			msg.WriteString("; ")
+		}
+		fmt.Fprintf(&msg, "%d file(s) in the build are not in the manifest: %s",
+			len(e.Orphans), strings.Join(e.Orphans, ", "))
+	}
+	return msg.String()
+}
+
+// Verify checks the manifest against DistFS: every file, css,
+// import and asset the chunk graph references must be there.
+// It returns a *VerifyError listing the missing files, along
+// with any orphans, files sitting next to the built assets that
+// the manifest knows nothing about.
+//
+// NewVueGlue and Reload run this in production, and refuse a
+// build with missing files (set SkipVerify to turn that off).
+// Orphans are only logged there, since they do no harm beyond
+// taking space; a stale file left over from an earlier build
+// is the usual cause.
+func (vg *VueGlue) Verify() error {
+	if vg.environment != "production" {
+		return ErrNotProduction
+	}
+	if vg.distFS == nil {
+		return ErrManifestDNF
+	}
+	return vg.verify(vg.state.Load())
+}
+
+// verify does the work for Verify against a snapshot that may
+// not be installed yet.
+func (vg *VueGlue) verify(snap *Snapshot) error {
+	referenced := map[string]bool{}
+	dirs := map[string]bool{}
+	var missing []string
+
+	check := func(file string) error {
+		if file == "" || referenced[file] {
+			return nil
+		}
+		referenced[file] = true
+		dirs[path.Dir(file)] = true
+		_, err := fs.Stat(vg.distFS, path.Join(vg.assetPath, file))
+		if errors.Is(err, fs.ErrNotExist) {
+			missing = append(missing, file)
+			return nil
+		}
+		return err
+	}
+
+	var stylesheets []string
+	for _, key := range snap.Manifest.Keys() {
+		chunk := snap.Manifest[key]
+		files := append([]string{chunk.File}, chunk.CSS...)
+		files = append(files, chunk.Assets...)
+		for _, file := range files {
+			if err := check(file); err != nil {
+				return err
+			}
+			if isStylesheet(file) {
+				stylesheets = append(stylesheets, file)
+			}
+		}
+		// imports are manifest keys, which must be there too
+		imports := append(append([]string(nil), chunk.Imports...), chunk.DynamicImports...)
+		for _, imported := range imports {
+			if _, ok := snap.Manifest[imported]; !ok && !referenced[imported] {
+				referenced[imported] = true
+				missing = append(missing, imported)
+			}
+		}
+	}
+	for _, file := range stylesheets {
+		if err := vg.markCSSURLs(file, referenced); err != nil {
+			return err
+		}
+	}
+
+	var orphans []string
+	for dir := range dirs {
+		// files at the top of dist (index.html, and whatever
+		// was in public/) are not the manifest's business.
+		if dir == "." {
+			continue
+		}
+		entries, err := fs.ReadDir(vg.distFS, path.Join(vg.assetPath, dir))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		for _, entry := range entries {
+			file := path.Join(dir, entry.Name())
+			if entry.IsDir() || referenced[file] || referenced[strings.TrimSuffix(file, ".map")] {
+				continue
+			}
+			orphans = append(orphans, file)
+		}
+	}
+
+	if len(missing) == 0 && len(orphans) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	sort.Strings(orphans)
+	return &VerifyError{Missing: missing, Orphans: orphans}
+}
+
+// markCSSURLs marks the files a built stylesheet loads through
+// url() as referenced. Vite copies these into the build without
+// listing them in the manifest. A root-relative URL includes the
+// build's base, which is not known here, so each of its trailing
+// paths is marked.
+func (vg *VueGlue) markCSSURLs(file string, referenced map[string]bool) error {
+	contents, err := fs.ReadFile(vg.distFS, path.Join(vg.assetPath, file))
+	if errors.Is(err, fs.ErrNotExist) {
+		// already reported as missing
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, match := range cssURLRE.FindAllSubmatch(contents, -1) {
+		ref := string(match[1])
+		if i := strings.IndexAny(ref, "?#"); i >= 0 {
+			ref = ref[:i]
+		}
+		if ref == "" || strings.HasPrefix(ref, "//") || schemeRE.MatchString(ref) {
+			continue
+		}
+		if !strings.HasPrefix(ref, "/") {
+			referenced[path.Join(path.Dir(file), ref)] = true
+			continue
+		}
+		for ref = strings.TrimPrefix(ref, "/"); ref != ""; {
+			referenced[ref] = true
+			_, rest, ok := strings.Cut(ref, "/")
+			if !ok {
+				break
+			}
+			ref = rest
+		}
+	}
+	return nil
+}
+
+// checkBuild runs verify for NewVueGlue and Reload. Orphans
+// are logged; only missing files are an error.
+func (vg *VueGlue) checkBuild(snap *Snapshot) error {
+	if vg.config.SkipVerify || vg.distFS == nil {
+		return nil
+	}
+	err := vg.verify(snap)
+	var verr *VerifyError
+	if errors.As(err, &verr) && len(verr.Missing) == 0 {
+		vg.log().Warn("build has files not in the manifest", "orphans", verr.Orphans)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,173 @@
+package vueglue
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// completeBuild is a dist directory for testdata/manifest.json.
+func completeBuild() fstest.MapFS {
+	manifest, _ := os.ReadFile("testdata/manifest.json")
+	return fstest.MapFS{
+		"dist/manifest.json":               {Data: manifest},
+		"dist/index.html":                  {Data: []byte(`<html></html>`)},
+		"dist/favicon.ico":                 {},
+		"dist/assets/main.9e2e52ce.js":     {Data: []byte(`export {}`)},
+		"dist/assets/main.9e2e52ce.js.map": {Data: []byte(`{}`)},
+		"dist/assets/main.0f2a382e.css":    {Data: []byte(`body{}`)},
+		"dist/assets/vendor.b43f27d7.js":   {Data: []byte(`export {}`)},
+		"dist/assets/logo.03d6d6da.png":    {},
+	}
+}
+
+func TestVerify(t *testing.T) {
+	build := completeBuild()
+	glue, err := NewVueGlue(&ViteConfig{Environment: "production", FS: build})
+	if err != nil {
+		t.Fatalf("complete build refused: %s", err)
+	}
+	if err := glue.Verify(); err != nil {
+		t.Errorf("complete build did not verify: %s", err)
+	}
+
+	// a stale file left by an earlier build
+	build["dist/assets/main.1a2b3c4d.js"] = &fstest.MapFile{}
+	err = glue.Verify()
+	var verr *VerifyError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a VerifyError, got %v", err)
+	}
+	if len(verr.Missing) != 0 || !reflect.DeepEqual(verr.Orphans, []string{"assets/main.1a2b3c4d.js"}) {
+		t.Errorf("unexpected report: %+v", verr)
+	}
+
+	// ...which does not stop the glue from starting
+	var logs bytes.Buffer
+	_, err = NewVueGlue(&ViteConfig{
+		Environment: "production",
+		FS:          build,
+		Logger:      slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if err != nil {
+		t.Errorf("orphans refused the build: %s", err)
+	}
+	if !strings.Contains(logs.String(), "assets/main.1a2b3c4d.js") {
+		t.Errorf("orphan not logged: %s", logs.String())
+	}
+}
+
+func TestVerifyMissing(t *testing.T) {
+	build := completeBuild()
+	delete(build, "dist/assets/main.9e2e52ce.js")
+	delete(build, "dist/assets/logo.03d6d6da.png")
+
+	_, err := NewVueGlue(&ViteConfig{Environment: "production", FS: build})
+	var verr *VerifyError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a VerifyError, got %v", err)
+	}
+	want := []string{"assets/logo.03d6d6da.png", "assets/main.9e2e52ce.js"}
+	if !reflect.DeepEqual(verr.Missing, want) {
+		t.Errorf("missing %v, want %v", verr.Missing, want)
+	}
+	if !strings.Contains(err.Error(), "assets/main.9e2e52ce.js") {
+		t.Errorf("error does not name the file: %s", err)
+	}
+
+	_, err = NewVueGlue(&ViteConfig{Environment: "production", FS: build, SkipVerify: true})
+	if err != nil {
+		t.Errorf("SkipVerify did not skip: %s", err)
+	}
+}
+
+func TestVerifyCSSURLs(t *testing.T) {
+	build := completeBuild()
+	build["dist/assets/main.0f2a382e.css"] = &fstest.MapFile{Data: []byte(
+		`@font-face{src:url(/static/assets/inter.5b1e.woff2) format("woff2"),url("./inter.7c2d.woff?v=2")}` +
+			`.bg{background:url('/assets/bg.9a8b.svg')}.x{clip-path:url(#clip);mask:url(data:image/png;base64,AAAA)}`,
+	)}
+	for _, file := range []string{"inter.5b1e.woff2", "inter.7c2d.woff", "bg.9a8b.svg"} {
+		build["dist/assets/"+file] = &fstest.MapFile{}
+	}
+	glue, err := NewVueGlue(&ViteConfig{Environment: "production", FS: build})
+	if err != nil {
+		t.Fatalf("build refused: %s", err)
+	}
+	if err := glue.Verify(); err != nil {
+		t.Errorf("files loaded by the CSS reported: %s", err)
+	}
+}
+
+func TestVerifyDynamicImports(t *testing.T) {
+	build := completeBuild()
+	build["dist/manifest.json"] = &fstest.MapFile{Data: []byte(`{
+  "src/main.ts": {"file": "assets/main.9e2e52ce.js", "src": "src/main.ts", "isEntry": true, "dynamicImports": ["src/About.vue"]}
+}`)}
+	delete(build, "dist/assets/vendor.b43f27d7.js")
+	delete(build, "dist/assets/main.0f2a382e.css")
+	delete(build, "dist/assets/logo.03d6d6da.png")
+
+	_, err := NewVueGlue(&ViteConfig{Environment: "production", FS: build})
+	var verr *VerifyError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a VerifyError, got %v", err)
+	}
+	if !reflect.DeepEqual(verr.Missing, []string{"src/About.vue"}) {
+		t.Errorf("unexpected missing %v", verr.Missing)
+	}
+}
+
+func TestVerifyReload(t *testing.T) {
+	glue, manifest := prodGlueFromDir(t)
+	dir := filepath.Dir(manifest)
+	for _, file := range []string{"main.9e2e52ce.js", "main.0f2a382e.css", "vendor.b43f27d7.js", "logo.03d6d6da.png"} {
+		path := filepath.Join(dir, "assets", file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	glue.config.SkipVerify = false
+
+	// the new manifest arrives before its bundle
+	next := `{"src/main.ts": {"file": "assets/main.5d6e7f80.js", "src": "src/main.ts", "isEntry": true}}`
+	if err := os.WriteFile(manifest, []byte(next), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var verr *VerifyError
+	if err := glue.Reload(); !errors.As(err, &verr) {
+		t.Fatalf("expected a VerifyError, got %v", err)
+	}
+	if glue.MainModule() != "assets/main.9e2e52ce.js" {
+		t.Errorf("half-copied build swapped in: %s", glue.MainModule())
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "assets", "main.5d6e7f80.js"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := glue.Reload(); err != nil {
+		t.Fatalf("reload failed: %s", err)
+	}
+	if glue.MainModule() != "assets/main.5d6e7f80.js" {
+		t.Errorf("new build not swapped in: %s", glue.MainModule())
+	}
+}
+
+func TestVerifyDevelopment(t *testing.T) {
+	glue, err := initializeVueGlue(nil)
+	if err != nil {
+		t.Fatalf("lib did not initialize: %s", err)
+	}
+	if err := glue.Verify(); !errors.Is(err, ErrNotProduction) {
+		t.Errorf("expected ErrNotProduction, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package vitechi_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing/fstest"
+
+	"github.com/go-chi/chi/v5"
+	vueglue "github.com/torenware/vite-go"
+	"github.com/torenware/vite-go/vitechi"
+)
+
+func Example() {
+	// In a real program, FS is an embed.FS or os.DirFS holding
+	// the frontend build.
+	dist := fstest.MapFS{
+		"dist/.vite/manifest.json": {Data: []byte(`{
+  "src/main.ts": {"file": "assets/main-4f1c.js", "src": "src/main.ts", "isEntry": true}
+}`)},
+		"dist/assets/main-4f1c.js": {Data: []byte("console.log('hello')\n")},
+	}
+	glue, err := vueglue.NewVueGlue(&vueglue.ViteConfig{
+		Environment: "production",
+		FS:          dist,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(vitechi.Middleware(glue))
+	if err := vitechi.Mount(r, glue); err != nil {
+		panic(err)
+	}
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		glue := vitechi.FromContext(r.Context())
+		fmt.Fprintf(w, "entry: %s", glue.MainModule())
+	})
+
+	for _, path := range []string{"/", "/assets/main-4f1c.js"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		fmt.Println(path, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+	// Output:
+	// / 200 entry: assets/main-4f1c.js
+	// /assets/main-4f1c.js 200 console.log('hello')
+}
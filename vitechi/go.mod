@@ -0,0 +1,8 @@
+module github.com/torenware/vite-go/vitechi
+
+go 1.23
+
+require (
+	github.com/go-chi/chi/v5 v5.3.1
+	github.com/torenware/vite-go v0.0.0-20261019043632-4019836cf308
+)
@@ -0,0 +1,74 @@
+// Package vitechi wires a VueGlue into a chi router:
+//
+//	r := chi.NewRouter()
+//	r.Use(vitechi.Middleware(glue))
+//	if err := vitechi.Mount(r, glue); err != nil {
+//		...
+//	}
+//
+// Mount serves the assets at the glue's URLPrefix, and in
+// development redirects /dev/ to the Vite dev server. Middleware
+// puts the glue in each request's context, for handlers to get
+// with FromContext.
+package vitechi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	vueglue "github.com/torenware/vite-go"
+)
+
+type contextKey struct{}
+
+// Mount adds the glue's routes to r: its FileServer at URLPrefix,
+// and in development, DevServerRedirector at /dev/. In a sub-router
+// (see chi.Router.Route), the routes are under its prefix, so with
+// the defaults, a sub-router at /app serves the assets at
+// /app/assets/.
+func Mount(r chi.Router, glue *vueglue.VueGlue) error {
+	fsHandler, err := glue.FileServer()
+	if err != nil {
+		return err
+	}
+	assets := glue.Config().URLPrefix + "*"
+	r.Handle(assets, wrap(fsHandler, assets))
+	if glue.Environment() == "development" {
+		dev := "/dev/*"
+		r.Handle(dev, wrap(glue.DevServerRedirector(), dev))
+	}
+	return nil
+}
+
+// wrap runs h for the route pattern, with the sub-router's prefix
+// stripped from the path: the glue's handlers expect the paths
+// they would see at the root.
+func wrap(h http.Handler, pattern string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := strings.TrimSuffix(chi.RouteContext(r.Context()).RoutePattern(), pattern)
+		http.StripPrefix(prefix, h).ServeHTTP(w, r)
+	})
+}
+
+// Middleware stores glue in the context of each request.
+func Middleware(glue *vueglue.VueGlue) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), glue)))
+		})
+	}
+}
+
+// NewContext returns a copy of ctx that carries glue.
+func NewContext(ctx context.Context, glue *vueglue.VueGlue) context.Context {
+	return context.WithValue(ctx, contextKey{}, glue)
+}
+
+// FromContext returns the glue Middleware stored in ctx, or nil
+// if there is none.
+func FromContext(ctx context.Context) *vueglue.VueGlue {
+	glue, _ := ctx.Value(contextKey{}).(*vueglue.VueGlue)
+	return glue
+}
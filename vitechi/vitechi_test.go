@@ -0,0 +1,123 @@
+package vitechi
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-chi/chi/v5"
+	vueglue "github.com/torenware/vite-go"
+	"github.com/torenware/vite-go/vitetest"
+)
+
+func newRouter(t *testing.T, glue *vueglue.VueGlue) chi.Router {
+	t.Helper()
+	r := chi.NewRouter()
+	r.Use(Middleware(glue))
+	if err := Mount(r, glue); err != nil {
+		t.Fatalf("could not mount the glue: %s", err)
+	}
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		tags, err := FromContext(r.Context()).RenderTags()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(tags))
+	})
+	return r
+}
+
+func serve(h http.Handler, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func page(w *httptest.ResponseRecorder) template.HTML {
+	return template.HTML(w.Body.String())
+}
+
+func TestProduction(t *testing.T) {
+	glue := vitetest.NewManifest().Entry("src/main.ts", "assets/main-4f1c.js").Glue(t)
+	r := newRouter(t, glue)
+
+	w := serve(r, http.MethodGet, "/")
+	if w.Code != http.StatusOK {
+		t.Fatalf("page: %d %s", w.Code, w.Body)
+	}
+	vitetest.AssertModuleScript(t, page(w), "/assets/main-4f1c.js")
+
+	if w := serve(r, http.MethodGet, "/assets/main-4f1c.js"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "vitetest") {
+		t.Errorf("asset: %d %s", w.Code, w.Body)
+	}
+	if w := serve(r, http.MethodHead, "/assets/main-4f1c.js"); w.Code != http.StatusOK {
+		t.Errorf("HEAD asset: %d", w.Code)
+	}
+	if w := serve(r, http.MethodGet, "/assets/missing.js"); w.Code != http.StatusNotFound {
+		t.Errorf("missing asset: %d", w.Code)
+	}
+	if w := serve(r, http.MethodGet, "/dev/src/main.ts"); w.Code != http.StatusNotFound {
+		t.Errorf("dev redirect mounted in production: %d", w.Code)
+	}
+}
+
+func TestDevelopment(t *testing.T) {
+	ds := vitetest.NewDevServer(t)
+	fsys := vitetest.ProjectFS(t, "vue")
+	fsys["src/main.ts"] = &fstest.MapFile{Data: []byte("export {}\n")}
+	fsys["src/.env"] = &fstest.MapFile{Data: []byte("SECRET=1\n")}
+	r := newRouter(t, ds.Glue(t, fsys, "src/main.ts"))
+
+	w := serve(r, http.MethodGet, "/")
+	vitetest.AssertModuleScript(t, page(w), ds.URL+"/src/main.ts")
+
+	if w := serve(r, http.MethodGet, "/src/main.ts"); w.Code != http.StatusOK {
+		t.Errorf("source file: %d", w.Code)
+	}
+	if w := serve(r, http.MethodGet, "/src/.env"); w.Code != http.StatusNotFound {
+		t.Errorf("dot file was served: %d", w.Code)
+	}
+	w = serve(r, http.MethodGet, "/dev/src/main.ts")
+	if w.Code != http.StatusPermanentRedirect || w.Header().Get("Location") != ds.URL+"/src/main.ts" {
+		t.Errorf("dev redirect: %d %q", w.Code, w.Header().Get("Location"))
+	}
+}
+
+func TestFromContextWithoutMiddleware(t *testing.T) {
+	if glue := FromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()); glue != nil {
+		t.Errorf("expected no glue, got %v", glue)
+	}
+}
+
+func TestMountSubRouter(t *testing.T) {
+	glue := vitetest.NewManifest().Entry("src/main.ts", "assets/main-4f1c.js").Glue(t)
+
+	r := chi.NewRouter()
+	r.Route("/app", func(r chi.Router) {
+		if err := Mount(r, glue); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if w := serve(r, http.MethodGet, "/app/assets/main-4f1c.js"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "vitetest") {
+		t.Errorf("asset through a sub-router: %d %s", w.Code, w.Body)
+	}
+	if w := serve(r, http.MethodGet, "/assets/main-4f1c.js"); w.Code != http.StatusNotFound {
+		t.Errorf("asset outside the sub-router: %d", w.Code)
+	}
+
+	ds := vitetest.NewDevServer(t)
+	dev := chi.NewRouter()
+	dev.Route("/app", func(r chi.Router) {
+		if err := Mount(r, ds.Glue(t, vitetest.ProjectFS(t, "vue"), "src/main.ts")); err != nil {
+			t.Fatal(err)
+		}
+	})
+	w := serve(dev, http.MethodGet, "/app/dev/src/main.ts")
+	if w.Code != http.StatusPermanentRedirect || w.Header().Get("Location") != ds.URL+"/src/main.ts" {
+		t.Errorf("dev redirect through a sub-router: %d %q", w.Code, w.Header().Get("Location"))
+	}
+}
@@ -0,0 +1,50 @@
+package viteecho_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing/fstest"
+
+	"github.com/labstack/echo/v4"
+	vueglue "github.com/torenware/vite-go"
+	"github.com/torenware/vite-go/viteecho"
+)
+
+func Example() {
+	// In a real program, FS is an embed.FS or os.DirFS holding
+	// the frontend build.
+	dist := fstest.MapFS{
+		"dist/.vite/manifest.json": {Data: []byte(`{
+  "src/main.ts": {"file": "assets/main-4f1c.js", "src": "src/main.ts", "isEntry": true}
+}`)},
+		"dist/assets/main-4f1c.js": {Data: []byte("console.log('hello')\n")},
+	}
+	glue, err := vueglue.NewVueGlue(&vueglue.ViteConfig{
+		Environment: "production",
+		FS:          dist,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	e := echo.New()
+	e.Use(viteecho.Middleware(glue))
+	if err := viteecho.Mount(e, glue); err != nil {
+		panic(err)
+	}
+	e.GET("/", func(c echo.Context) error {
+		glue := viteecho.FromContext(c)
+		return c.String(http.StatusOK, "entry: "+glue.MainModule())
+	})
+
+	for _, path := range []string{"/", "/assets/main-4f1c.js"} {
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		fmt.Println(path, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+	// Output:
+	// / 200 entry: assets/main-4f1c.js
+	// /assets/main-4f1c.js 200 console.log('hello')
+}
@@ -0,0 +1,77 @@
+// Package viteecho wires a VueGlue into an echo server:
+//
+//	e := echo.New()
+//	e.Use(viteecho.Middleware(glue))
+//	if err := viteecho.Mount(e, glue); err != nil {
+//		...
+//	}
+//
+// Mount serves the assets at the glue's URLPrefix, and in
+// development redirects /dev/ to the Vite dev server. Middleware
+// puts the glue in each request's echo.Context, for handlers to
+// get with FromContext.
+package viteecho
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	vueglue "github.com/torenware/vite-go"
+)
+
+// ContextKey is the key Middleware stores the glue under.
+const ContextKey = "vite-go"
+
+// Router is what Mount adds routes to: an *echo.Echo or an
+// *echo.Group.
+type Router interface {
+	Add(method, path string, handler echo.HandlerFunc, middleware ...echo.MiddlewareFunc) *echo.Route
+}
+
+// Mount adds the glue's routes to r: its FileServer at URLPrefix,
+// and in development, DevServerRedirector at /dev/. In a group,
+// the routes are under the group's prefix, so with the defaults,
+// a group at /app serves the assets at /app/assets/.
+func Mount(r Router, glue *vueglue.VueGlue) error {
+	fsHandler, err := glue.FileServer()
+	if err != nil {
+		return err
+	}
+	assets := glue.Config().URLPrefix + "*"
+	r.Add(http.MethodGet, assets, wrap(fsHandler, assets))
+	r.Add(http.MethodHead, assets, wrap(fsHandler, assets))
+	if glue.Environment() == "development" {
+		dev := "/dev/*"
+		r.Add(http.MethodGet, dev, wrap(glue.DevServerRedirector(), dev))
+	}
+	return nil
+}
+
+// wrap runs h for the route pattern, with the group's prefix
+// stripped from the path: the glue's handlers expect the paths
+// they would see at the root.
+func wrap(h http.Handler, pattern string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		prefix := strings.TrimSuffix(c.Path(), pattern)
+		http.StripPrefix(prefix, h).ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
+
+// Middleware stores glue in the context of each request.
+func Middleware(glue *vueglue.VueGlue) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(ContextKey, glue)
+			return next(c)
+		}
+	}
+}
+
+// FromContext returns the glue Middleware stored in c, or nil if
+// there is none.
+func FromContext(c echo.Context) *vueglue.VueGlue {
+	glue, _ := c.Get(ContextKey).(*vueglue.VueGlue)
+	return glue
+}
@@ -0,0 +1,55 @@
+package vitefiber_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing/fstest"
+
+	"github.com/gofiber/fiber/v2"
+	vueglue "github.com/torenware/vite-go"
+	"github.com/torenware/vite-go/vitefiber"
+)
+
+func Example() {
+	// In a real program, FS is an embed.FS or os.DirFS holding
+	// the frontend build.
+	dist := fstest.MapFS{
+		"dist/.vite/manifest.json": {Data: []byte(`{
+  "src/main.ts": {"file": "assets/main-4f1c.js", "src": "src/main.ts", "isEntry": true}
+}`)},
+		"dist/assets/main-4f1c.js": {Data: []byte("console.log('hello')\n")},
+	}
+	glue, err := vueglue.NewVueGlue(&vueglue.ViteConfig{
+		Environment: "production",
+		FS:          dist,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	app := fiber.New()
+	app.Use(vitefiber.Middleware(glue))
+	if err := vitefiber.Mount(app, glue); err != nil {
+		panic(err)
+	}
+	app.Get("/", func(c *fiber.Ctx) error {
+		glue := vitefiber.FromContext(c)
+		return c.SendString("entry: " + glue.MainModule())
+	})
+
+	for _, path := range []string{"/", "/assets/main-4f1c.js"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		if err != nil {
+			panic(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		fmt.Println(path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	// Output:
+	// / 200 entry: assets/main-4f1c.js
+	// /assets/main-4f1c.js 200 console.log('hello')
+}
@@ -0,0 +1,75 @@
+// Package vitefiber wires a VueGlue into a fiber app:
+//
+//	app := fiber.New()
+//	app.Use(vitefiber.Middleware(glue))
+//	if err := vitefiber.Mount(app, glue); err != nil {
+//		...
+//	}
+//
+// Mount serves the assets at the glue's URLPrefix, and in
+// development redirects /dev/ to the Vite dev server. Middleware
+// puts the glue in each request's Locals, for handlers to get
+// with FromContext.
+//
+// fiber is not built on net/http; the glue's handlers are run
+// through fiber's adaptor, which copies each request and response.
+// That is fine for development, but for a busy production site
+// you may prefer fiber's own static middleware over the dist
+// directory.
+package vitefiber
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	vueglue "github.com/torenware/vite-go"
+)
+
+// ContextKey is the Locals key Middleware stores the glue under.
+const ContextKey = "vite-go"
+
+// Mount adds the glue's routes to r, which may be an app or a
+// group: its FileServer at URLPrefix, and in development,
+// DevServerRedirector at /dev/. In a group, the routes are under
+// the group's prefix, so with the defaults, a group at /app serves
+// the assets at /app/assets/.
+func Mount(r fiber.Router, glue *vueglue.VueGlue) error {
+	fsHandler, err := glue.FileServer()
+	if err != nil {
+		return err
+	}
+	assets := glue.Config().URLPrefix + "*"
+	r.Get(assets, wrap(fsHandler, assets))
+	if glue.Environment() == "development" {
+		dev := "/dev/*"
+		r.Get(dev, wrap(glue.DevServerRedirector(), dev))
+	}
+	return nil
+}
+
+// wrap runs h for the route pattern, with the group's prefix
+// stripped from the path: the glue's handlers expect the paths
+// they would see at the root.
+func wrap(h http.Handler, pattern string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		prefix := strings.TrimSuffix(c.Route().Path, pattern)
+		return adaptor.HTTPHandler(http.StripPrefix(prefix, h))(c)
+	}
+}
+
+// Middleware stores glue in the Locals of each request.
+func Middleware(glue *vueglue.VueGlue) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(ContextKey, glue)
+		return c.Next()
+	}
+}
+
+// FromContext returns the glue Middleware stored in c, or nil if
+// there is none.
+func FromContext(c *fiber.Ctx) *vueglue.VueGlue {
+	glue, _ := c.Locals(ContextKey).(*vueglue.VueGlue)
+	return glue
+}
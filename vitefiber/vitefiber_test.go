@@ -0,0 +1,135 @@
+package vitefiber
+
+import (
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gofiber/fiber/v2"
+	vueglue "github.com/torenware/vite-go"
+	"github.com/torenware/vite-go/vitetest"
+)
+
+func newApp(t *testing.T, glue *vueglue.VueGlue) *fiber.App {
+	t.Helper()
+	app := fiber.New()
+	app.Use(Middleware(glue))
+	if err := Mount(app, glue); err != nil {
+		t.Fatalf("could not mount the glue: %s", err)
+	}
+	app.Get("/", func(c *fiber.Ctx) error {
+		tags, err := FromContext(c).RenderTags()
+		if err != nil {
+			return err
+		}
+		c.Type("html")
+		return c.SendString(string(tags))
+	})
+	return app
+}
+
+type response struct {
+	code     int
+	body     string
+	location string
+}
+
+func serve(t *testing.T, app *fiber.App, method, path string) response {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(method, path, nil))
+	if err != nil {
+		t.Fatalf("%s %s: %s", method, path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response{resp.StatusCode, string(body), resp.Header.Get("Location")}
+}
+
+func TestProduction(t *testing.T) {
+	glue := vitetest.NewManifest().Entry("src/main.ts", "assets/main-4f1c.js").Glue(t)
+	app := newApp(t, glue)
+
+	r := serve(t, app, http.MethodGet, "/")
+	if r.code != http.StatusOK {
+		t.Fatalf("page: %d %s", r.code, r.body)
+	}
+	vitetest.AssertModuleScript(t, template.HTML(r.body), "/assets/main-4f1c.js")
+
+	if r := serve(t, app, http.MethodGet, "/assets/main-4f1c.js"); r.code != http.StatusOK || !strings.Contains(r.body, "vitetest") {
+		t.Errorf("asset: %d %s", r.code, r.body)
+	}
+	if r := serve(t, app, http.MethodHead, "/assets/main-4f1c.js"); r.code != http.StatusOK {
+		t.Errorf("HEAD asset: %d", r.code)
+	}
+	if r := serve(t, app, http.MethodGet, "/assets/missing.js"); r.code != http.StatusNotFound {
+		t.Errorf("missing asset: %d", r.code)
+	}
+	if r := serve(t, app, http.MethodGet, "/dev/src/main.ts"); r.code != http.StatusNotFound {
+		t.Errorf("dev redirect mounted in production: %d", r.code)
+	}
+}
+
+func TestDevelopment(t *testing.T) {
+	ds := vitetest.NewDevServer(t)
+	fsys := vitetest.ProjectFS(t, "vue")
+	fsys["src/main.ts"] = &fstest.MapFile{Data: []byte("export {}\n")}
+	fsys["src/.env"] = &fstest.MapFile{Data: []byte("SECRET=1\n")}
+	app := newApp(t, ds.Glue(t, fsys, "src/main.ts"))
+
+	r := serve(t, app, http.MethodGet, "/")
+	vitetest.AssertModuleScript(t, template.HTML(r.body), ds.URL+"/src/main.ts")
+
+	if r := serve(t, app, http.MethodGet, "/src/main.ts"); r.code != http.StatusOK {
+		t.Errorf("source file: %d", r.code)
+	}
+	if r := serve(t, app, http.MethodGet, "/src/.env"); r.code != http.StatusNotFound {
+		t.Errorf("dot file was served: %d", r.code)
+	}
+	r = serve(t, app, http.MethodGet, "/dev/src/main.ts")
+	if r.code != http.StatusPermanentRedirect || r.location != ds.URL+"/src/main.ts" {
+		t.Errorf("dev redirect: %d %q", r.code, r.location)
+	}
+}
+
+func TestMountGroup(t *testing.T) {
+	glue := vitetest.NewManifest().Entry("src/main.ts", "assets/main-4f1c.js").Glue(t)
+
+	app := fiber.New()
+	if err := Mount(app.Group("/app"), glue); err != nil {
+		t.Fatal(err)
+	}
+	if r := serve(t, app, http.MethodGet, "/app/assets/main-4f1c.js"); r.code != http.StatusOK || !strings.Contains(r.body, "vitetest") {
+		t.Errorf("asset through a group: %d %s", r.code, r.body)
+	}
+	if r := serve(t, app, http.MethodGet, "/assets/main-4f1c.js"); r.code != http.StatusNotFound {
+		t.Errorf("asset outside the group: %d", r.code)
+	}
+
+	ds := vitetest.NewDevServer(t)
+	dev := fiber.New()
+	if err := Mount(dev.Group("/app"), ds.Glue(t, vitetest.ProjectFS(t, "vue"), "src/main.ts")); err != nil {
+		t.Fatal(err)
+	}
+	r := serve(t, dev, http.MethodGet, "/app/dev/src/main.ts")
+	if r.code != http.StatusPermanentRedirect || r.location != ds.URL+"/src/main.ts" {
+		t.Errorf("dev redirect through a group: %d %q", r.code, r.location)
+	}
+}
+
+func TestFromContextWithoutMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if glue := FromContext(c); glue != nil {
+			t.Errorf("expected no glue, got %v", glue)
+		}
+		return nil
+	})
+	serve(t, app, http.MethodGet, "/")
+}
@@ -0,0 +1,51 @@
+package vitegin_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	vueglue "github.com/torenware/vite-go"
+	"github.com/torenware/vite-go/vitegin"
+)
+
+func Example() {
+	// In a real program, FS is an embed.FS or os.DirFS holding
+	// the frontend build.
+	dist := fstest.MapFS{
+		"dist/.vite/manifest.json": {Data: []byte(`{
+  "src/main.ts": {"file": "assets/main-4f1c.js", "src": "src/main.ts", "isEntry": true}
+}`)},
+		"dist/assets/main-4f1c.js": {Data: []byte("console.log('hello')\n")},
+	}
+	glue, err := vueglue.NewVueGlue(&vueglue.ViteConfig{
+		Environment: "production",
+		FS:          dist,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(vitegin.Middleware(glue))
+	if err := vitegin.Mount(r, glue); err != nil {
+		panic(err)
+	}
+	r.GET("/", func(c *gin.Context) {
+		glue := vitegin.FromContext(c)
+		c.String(http.StatusOK, "entry: %s", glue.MainModule())
+	})
+
+	for _, path := range []string{"/", "/assets/main-4f1c.js"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		fmt.Println(path, w.Code, strings.TrimSpace(w.Body.String()))
+	}
+	// Output:
+	// / 200 entry: assets/main-4f1c.js
+	// /assets/main-4f1c.js 200 console.log('hello')
+}
@@ -0,0 +1,74 @@
+// Package vitegin wires a VueGlue into a gin engine:
+//
+//	r := gin.New()
+//	r.Use(vitegin.Middleware(glue))
+//	if err := vitegin.Mount(r, glue); err != nil {
+//		...
+//	}
+//
+// Mount serves the assets at the glue's URLPrefix, and in
+// development redirects /dev/ to the Vite dev server. Middleware
+// puts the glue in each request's gin.Context, for handlers to get
+// with FromContext.
+//
+// gin does not let a wildcard route share a path segment with
+// other routes, so URLPrefix must be a directory of its own, as
+// the defaults (/src/ and /assets/) are.
+package vitegin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	vueglue "github.com/torenware/vite-go"
+)
+
+// ContextKey is the key Middleware stores the glue under.
+const ContextKey = "vite-go"
+
+// Mount adds the glue's routes to r, which may be an engine or a
+// group: its FileServer at URLPrefix, and in development,
+// DevServerRedirector at /dev/. In a group, the routes are under
+// the group's prefix, so with the defaults, a group at /app serves
+// the assets at /app/assets/.
+func Mount(r gin.IRoutes, glue *vueglue.VueGlue) error {
+	fsHandler, err := glue.FileServer()
+	if err != nil {
+		return err
+	}
+	assets := glue.Config().URLPrefix + "*filepath"
+	r.GET(assets, wrap(fsHandler, assets))
+	r.HEAD(assets, wrap(fsHandler, assets))
+	if glue.Environment() == "development" {
+		dev := "/dev/*filepath"
+		r.GET(dev, wrap(glue.DevServerRedirector(), dev))
+	}
+	return nil
+}
+
+// wrap runs h for the route pattern, with the group's prefix
+// stripped from the path: the glue's handlers expect the paths
+// they would see at the root.
+func wrap(h http.Handler, pattern string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		prefix := strings.TrimSuffix(c.FullPath(), pattern)
+		http.StripPrefix(prefix, h).ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// Middleware stores glue in the context of each request.
+func Middleware(glue *vueglue.VueGlue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ContextKey, glue)
+		c.Next()
+	}
+}
+
+// FromContext returns the glue Middleware stored in c, or nil if
+// there is none.
+func FromContext(c *gin.Context) *vueglue.VueGlue {
+	value, _ := c.Get(ContextKey)
+	glue, _ := value.(*vueglue.VueGlue)
+	return glue
+}
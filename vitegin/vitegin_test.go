@@ -0,0 +1,130 @@
+package vitegin
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	vueglue "github.com/torenware/vite-go"
+	"github.com/torenware/vite-go/vitetest"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newEngine(t *testing.T, glue *vueglue.VueGlue) *gin.Engine {
+	t.Helper()
+	r := gin.New()
+	r.Use(Middleware(glue))
+	if err := Mount(r, glue); err != nil {
+		t.Fatalf("could not mount the glue: %s", err)
+	}
+	r.GET("/", func(c *gin.Context) {
+		tags, err := FromContext(c).RenderTags()
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(tags))
+	})
+	return r
+}
+
+func serve(h http.Handler, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func page(w *httptest.ResponseRecorder) template.HTML {
+	return template.HTML(w.Body.String())
+}
+
+func TestProduction(t *testing.T) {
+	glue := vitetest.NewManifest().Entry("src/main.ts", "assets/main-4f1c.js").Glue(t)
+	r := newEngine(t, glue)
+
+	w := serve(r, http.MethodGet, "/")
+	if w.Code != http.StatusOK {
+		t.Fatalf("page: %d %s", w.Code, w.Body)
+	}
+	vitetest.AssertModuleScript(t, page(w), "/assets/main-4f1c.js")
+
+	if w := serve(r, http.MethodGet, "/assets/main-4f1c.js"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "vitetest") {
+		t.Errorf("asset: %d %s", w.Code, w.Body)
+	}
+	if w := serve(r, http.MethodHead, "/assets/main-4f1c.js"); w.Code != http.StatusOK {
+		t.Errorf("HEAD asset: %d", w.Code)
+	}
+	if w := serve(r, http.MethodGet, "/assets/missing.js"); w.Code != http.StatusNotFound {
+		t.Errorf("missing asset: %d", w.Code)
+	}
+	if w := serve(r, http.MethodGet, "/dev/src/main.ts"); w.Code != http.StatusNotFound {
+		t.Errorf("dev redirect mounted in production: %d", w.Code)
+	}
+}
+
+func TestDevelopment(t *testing.T) {
+	ds := vitetest.NewDevServer(t)
+	fsys := vitetest.ProjectFS(t, "vue")
+	fsys["src/main.ts"] = &fstest.MapFile{Data: []byte("export {}\n")}
+	fsys["src/.env"] = &fstest.MapFile{Data: []byte("SECRET=1\n")}
+	r := newEngine(t, ds.Glue(t, fsys, "src/main.ts"))
+
+	w := serve(r, http.MethodGet, "/")
+	vitetest.AssertModuleScript(t, page(w), ds.URL+"/src/main.ts")
+
+	if w := serve(r, http.MethodGet, "/src/main.ts"); w.Code != http.StatusOK {
+		t.Errorf("source file: %d", w.Code)
+	}
+	if w := serve(r, http.MethodGet, "/src/.env"); w.Code != http.StatusNotFound {
+		t.Errorf("dot file was served: %d", w.Code)
+	}
+	w = serve(r, http.MethodGet, "/dev/src/main.ts")
+	if w.Code != http.StatusPermanentRedirect || w.Header().Get("Location") != ds.URL+"/src/main.ts" {
+		t.Errorf("dev redirect: %d %q", w.Code, w.Header().Get("Location"))
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	glue := vitetest.NewManifest().Entry("src/main.ts", "assets/main-4f1c.js").Glue(t)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if FromContext(c) != nil {
+		t.Errorf("expected no glue before the middleware ran")
+	}
+	Middleware(glue)(c)
+	if FromContext(c) != glue {
+		t.Errorf("middleware did not store the glue")
+	}
+}
+
+func TestMountGroup(t *testing.T) {
+	glue := vitetest.NewManifest().Entry("src/main.ts", "assets/main-4f1c.js").Glue(t)
+
+	r := gin.New()
+	if err := Mount(r.Group("/app"), glue); err != nil {
+		t.Fatal(err)
+	}
+	if w := serve(r, http.MethodGet, "/app/assets/main-4f1c.js"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "vitetest") {
+		t.Errorf("asset through a group: %d %s", w.Code, w.Body)
+	}
+	if w := serve(r, http.MethodGet, "/assets/main-4f1c.js"); w.Code != http.StatusNotFound {
+		t.Errorf("asset outside the group: %d", w.Code)
+	}
+
+	ds := vitetest.NewDevServer(t)
+	dev := gin.New()
+	if err := Mount(dev.Group("/app"), ds.Glue(t, vitetest.ProjectFS(t, "vue"), "src/main.ts")); err != nil {
+		t.Fatal(err)
+	}
+	w := serve(dev, http.MethodGet, "/app/dev/src/main.ts")
+	if w.Code != http.StatusPermanentRedirect || w.Header().Get("Location") != ds.URL+"/src/main.ts" {
+		t.Errorf("dev redirect through a group: %d %q", w.Code, w.Header().Get("Location"))
+	}
+}
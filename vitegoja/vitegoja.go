@@ -0,0 +1,312 @@
+// Package vitegoja renders a Vite SSR bundle inside goja, a
+// JavaScript engine written in pure Go, for deployments that
+// cannot ship Node. A Renderer implements vueglue.SSRRenderer:
+//
+//	renderer, err := vitegoja.NewFromFS(dist, "server/entry-server.js", vitegoja.Options{})
+//	config.SSR = renderer
+//	glue, err := vueglue.NewVueGlue(config)
+//	page, err := glue.Render(ctx, r.URL.Path, props)
+//
+// goja does not load ES modules or provide Node's APIs, so the
+// bundle must be self-contained and assign its exports to a
+// global. With Vite that means building it like so:
+//
+//	export default defineConfig({
+//	  ssr: { target: 'webworker', noExternal: true },
+//	  build: {
+//	    ssr: 'src/entry-server.js',
+//	    rollupOptions: { output: { format: 'iife', name: 'ssr' } },
+//	  },
+//	})
+//
+// The bundle's render(url, props) function may return an HTML
+// string, or an object with html, head and modules properties,
+// or a Promise of either, as it would under the Node renderer.
+package vitegoja
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"runtime"
+
+	"github.com/dop251/goja"
+	vueglue "github.com/torenware/vite-go"
+)
+
+var (
+	ErrNoRender   = errors.New("SSR bundle does not define a render function")
+	ErrNotSettled = errors.New("render returned a promise that never settled")
+)
+
+// normalizeScript turns whatever render returned into a JSON
+// string we can unmarshal into a vueglue.SSROutput.
+const normalizeScript = `(function (out) {
+  if (typeof out === 'string') {
+    out = { html: out };
+  }
+  out = out || {};
+  return JSON.stringify({
+    html: String(out.html ?? ''),
+    head: String(out.head ?? ''),
+    modules: Array.from(out.modules ?? []),
+  });
+})`
+
+// Options configures a Renderer.
+type Options struct {
+	// Global is the name the bundle's exports are assigned to
+	// (rollupOptions.output.name). If the global has no render
+	// function, a global render function is used instead.
+	// Default is "ssr".
+	Global string
+
+	// PoolSize is the most runtimes kept at once, and so the
+	// most renders that run in parallel.
+	// Default is runtime.GOMAXPROCS(0).
+	PoolSize int
+
+	// Logger receives the bundle's console output.
+	// Default is slog.Default().
+	Logger *slog.Logger
+}
+
+// Renderer runs an SSR bundle in a pool of goja runtimes. Each
+// runtime is used by one goroutine at a time, so the bundle's
+// module state is never shared between concurrent renders.
+type Renderer struct {
+	program   *goja.Program
+	normalize *goja.Program
+	global    string
+	logger    *slog.Logger
+
+	// slots bounds the number of runtimes; idle holds the ones
+	// not currently rendering.
+	slots chan struct{}
+	idle  chan *jsRuntime
+}
+
+// jsRuntime is one initialized copy of the bundle.
+type jsRuntime struct {
+	vm        *goja.Runtime
+	render    goja.Callable
+	normalize goja.Callable
+}
+
+// New compiles an SSR bundle. name is used in stack traces.
+// The bundle is run once here, so a broken bundle fails now
+// rather than on the first request.
+func New(name string, bundle []byte, opts Options) (*Renderer, error) {
+	program, err := goja.Compile(name, string(bundle), false)
+	if err != nil {
+		return nil, err
+	}
+	normalize, err := goja.Compile("vitegoja-normalize", normalizeScript, false)
+	if err != nil {
+		return nil, err
+	}
+
+	size := opts.PoolSize
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+	r := &Renderer{
+		program:   program,
+		normalize: normalize,
+		global:    opts.Global,
+		logger:    opts.Logger,
+		slots:     make(chan struct{}, size),
+		idle:      make(chan *jsRuntime, size),
+	}
+	if r.global == "" {
+		r.global = "ssr"
+	}
+	if r.logger == nil {
+		r.logger = slog.Default()
+	}
+
+	// Warm the pool with one runtime.
+	rt, err := r.newRuntime()
+	if err != nil {
+		return nil, err
+	}
+	r.idle <- rt
+
+	return r, nil
+}
+
+// NewFromFS compiles the SSR bundle at path in fsys; typically
+// the embedded dist directory.
+func NewFromFS(fsys fs.FS, path string, opts Options) (*Renderer, error) {
+	bundle, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	return New(path, bundle, opts)
+}
+
+// newRuntime creates a runtime and runs the bundle in it.
+func (r *Renderer) newRuntime() (*jsRuntime, error) {
+	vm := goja.New()
+	if err := r.installConsole(vm); err != nil {
+		return nil, err
+	}
+
+	if _, err := vm.RunProgram(r.program); err != nil {
+		return nil, err
+	}
+
+	render, ok := goja.AssertFunction(vm.Get("render"))
+	if exports := vm.Get(r.global); exports != nil && !goja.IsUndefined(exports) && !goja.IsNull(exports) {
+		if fn, found := goja.AssertFunction(exports.ToObject(vm).Get("render")); found {
+			render, ok = fn, true
+		}
+	}
+	if !ok {
+		return nil, ErrNoRender
+	}
+
+	normalizeValue, err := vm.RunProgram(r.normalize)
+	if err != nil {
+		return nil, err
+	}
+	normalize, _ := goja.AssertFunction(normalizeValue)
+
+	return &jsRuntime{
+		vm:        vm,
+		render:    render,
+		normalize: normalize,
+	}, nil
+}
+
+// installConsole routes console.* to the logger.
+func (r *Renderer) installConsole(vm *goja.Runtime) error {
+	console := vm.NewObject()
+	logTo := func(level slog.Level) func(goja.FunctionCall) goja.Value {
+		return func(call goja.FunctionCall) goja.Value {
+			args := make([]interface{}, len(call.Arguments))
+			for i, arg := range call.Arguments {
+				args[i] = arg.String()
+			}
+			r.logger.Log(context.Background(), level, "SSR console", "args", args)
+			return goja.Undefined()
+		}
+	}
+	for name, level := range map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"log":   slog.LevelInfo,
+		"info":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+	} {
+		if err := console.Set(name, logTo(level)); err != nil {
+			return err
+		}
+	}
+	return vm.Set("console", console)
+}
+
+// get takes a runtime from the pool, creating one if the
+// pool is not yet full.
+func (r *Renderer) get(ctx context.Context) (*jsRuntime, error) {
+	select {
+	case r.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case rt := <-r.idle:
+		return rt, nil
+	default:
+	}
+
+	rt, err := r.newRuntime()
+	if err != nil {
+		<-r.slots
+		return nil, err
+	}
+	return rt, nil
+}
+
+// put returns a runtime to the pool. A nil runtime (one we
+// had to interrupt) just frees its slot.
+func (r *Renderer) put(rt *jsRuntime) {
+	if rt != nil {
+		r.idle <- rt
+	}
+	<-r.slots
+}
+
+// Render implements vueglue.SSRRenderer. props are passed to
+// the bundle as plain JSON data.
+func (r *Renderer) Render(ctx context.Context, url string, props interface{}) (*vueglue.SSROutput, error) {
+	var jsProps interface{}
+	if props != nil {
+		buf, err := json.Marshal(props)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(buf, &jsProps); err != nil {
+			return nil, err
+		}
+	}
+
+	rt, err := r.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Interrupt the runtime if ctx ends mid-render. An
+	// interrupted runtime is not reused.
+	stop := context.AfterFunc(ctx, func() {
+		rt.vm.Interrupt(ctx.Err())
+	})
+
+	out, err := rt.run(url, jsProps)
+
+	if !stop() {
+		r.put(nil)
+		if err == nil {
+			err = ctx.Err()
+		}
+		return nil, err
+	}
+	r.put(rt)
+
+	return out, err
+}
+
+// run calls render, and waits out any promise it returns.
+func (rt *jsRuntime) run(url string, props interface{}) (*vueglue.SSROutput, error) {
+	value, err := rt.render(goja.Undefined(), rt.vm.ToValue(url), rt.vm.ToValue(props))
+	if err != nil {
+		return nil, err
+	}
+
+	// goja runs queued promise jobs before a call returns, so
+	// anything that can settle already has.
+	if promise, ok := value.Export().(*goja.Promise); ok {
+		switch promise.State() {
+		case goja.PromiseStateFulfilled:
+			value = promise.Result()
+		case goja.PromiseStateRejected:
+			return nil, fmt.Errorf("render rejected: %s", promise.Result())
+		default:
+			return nil, ErrNotSettled
+		}
+	}
+
+	encoded, err := rt.normalize(goja.Undefined(), value)
+	if err != nil {
+		return nil, err
+	}
+	out := &vueglue.SSROutput{}
+	if err := json.Unmarshal([]byte(encoded.String()), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,190 @@
+package vitegoja
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+// bundle mimics the shape of an iife SSR build.
+const bundle = `
+var ssr = (function (exports) {
+  'use strict';
+  let renders = 0;
+  async function render(url, props) {
+    renders++;
+    if (url === '/boom') {
+      throw new Error('render exploded');
+    }
+    if (url === '/loop') {
+      for (;;) {}
+    }
+    if (url === '/never') {
+      return new Promise(function () {});
+    }
+    console.log('rendering', url);
+    const ctx = { modules: new Set(['src/App.vue']) };
+    await Promise.resolve();
+    return {
+      html: '<div id="app">' + url + ' ' + props.name + ' #' + renders + '</div>',
+      head: '<title>' + props.name + '</title>',
+      modules: ctx.modules,
+    };
+  }
+  exports.render = render;
+  return exports;
+})({});
+`
+
+var quiet = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestRender(t *testing.T) {
+	renderer, err := New("entry-server.js", []byte(bundle), Options{Logger: quiet})
+	if err != nil {
+		t.Fatalf("bundle did not load: %s", err)
+	}
+
+	out, err := renderer.Render(context.Background(), "/about", map[string]string{"name": "vite"})
+	if err != nil {
+		t.Fatalf("render failed: %s", err)
+	}
+	if out.HTML != `<div id="app">/about vite #1</div>` {
+		t.Errorf("unexpected html %q", out.HTML)
+	}
+	if out.Head != `<title>vite</title>` {
+		t.Errorf("unexpected head %q", out.Head)
+	}
+	if len(out.Modules) != 1 || out.Modules[0] != "src/App.vue" {
+		t.Errorf("unexpected modules %v", out.Modules)
+	}
+
+	_, err = renderer.Render(context.Background(), "/boom", nil)
+	if err == nil || !strings.Contains(err.Error(), "render exploded") {
+		t.Errorf("expected a rejected render, got %v", err)
+	}
+
+	_, err = renderer.Render(context.Background(), "/never", nil)
+	if !errors.Is(err, ErrNotSettled) {
+		t.Errorf("expected ErrNotSettled, got %v", err)
+	}
+}
+
+func TestRenderConcurrent(t *testing.T) {
+	renderer, err := New("entry-server.js", []byte(bundle), Options{PoolSize: 3, Logger: quiet})
+	if err != nil {
+		t.Fatalf("bundle did not load: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("user%d", i)
+			out, err := renderer.Render(context.Background(), "/", map[string]string{"name": name})
+			if err != nil {
+				t.Errorf("render failed: %s", err)
+				return
+			}
+			if !strings.Contains(out.HTML, "/ "+name+" #") {
+				t.Errorf("%s: got %q", name, out.HTML)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := len(renderer.idle); n > 3 {
+		t.Errorf("pool grew to %d runtimes", n)
+	}
+}
+
+func TestRenderCancelled(t *testing.T) {
+	renderer, err := New("entry-server.js", []byte(bundle), Options{PoolSize: 1, Logger: quiet})
+	if err != nil {
+		t.Fatalf("bundle did not load: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = renderer.Render(ctx, "/loop", nil)
+	if err == nil {
+		t.Fatalf("runaway render was not interrupted")
+	}
+
+	// The interrupted runtime is replaced, not reused.
+	out, err := renderer.Render(context.Background(), "/", map[string]string{"name": "after"})
+	if err != nil {
+		t.Fatalf("render after interrupt failed: %s", err)
+	}
+	if !strings.Contains(out.HTML, "after #1") {
+		t.Errorf("expected a fresh runtime, got %q", out.HTML)
+	}
+}
+
+func TestGlobalRender(t *testing.T) {
+	renderer, err := New("plain.js", []byte(`function render(url) { return '<p>' + url + '</p>'; }`), Options{Logger: quiet})
+	if err != nil {
+		t.Fatalf("bundle did not load: %s", err)
+	}
+	out, err := renderer.Render(context.Background(), "/plain", nil)
+	if err != nil {
+		t.Fatalf("render failed: %s", err)
+	}
+	if out.HTML != "<p>/plain</p>" || out.Modules == nil {
+		t.Errorf("unexpected output %+v", out)
+	}
+
+	if _, err := New("empty.js", []byte(`var x = 1;`), Options{}); !errors.Is(err, ErrNoRender) {
+		t.Errorf("expected ErrNoRender, got %v", err)
+	}
+	if _, err := New("broken.js", []byte(`function (`), Options{}); err == nil {
+		t.Errorf("expected a syntax error")
+	}
+}
+
+func TestWithGlue(t *testing.T) {
+	dist := fstest.MapFS{
+		"dist/manifest.json": {Data: []byte(`{
+  "src/main.ts": {"file": "assets/main.1234.js", "src": "src/main.ts", "isEntry": true}
+}`)},
+		"dist/ssr-manifest.json":      {Data: []byte(`{"src/App.vue": ["/assets/App.5678.js"]}`)},
+		"dist/server/entry-server.js": {Data: []byte(bundle)},
+		"dist/assets/main.1234.js":    {Data: []byte(`export {}`)},
+	}
+
+	renderer, err := NewFromFS(dist, "dist/server/entry-server.js", Options{Logger: quiet})
+	if err != nil {
+		t.Fatalf("bundle did not load: %s", err)
+	}
+	glue, err := vueglue.NewVueGlue(&vueglue.ViteConfig{
+		Environment: "production",
+		FS:          dist,
+		SSR:         renderer,
+	})
+	if err != nil {
+		t.Fatalf("could not create glue: %s", err)
+	}
+
+	page, err := glue.Render(context.Background(), "/", map[string]string{"name": "glue"})
+	if err != nil {
+		t.Fatalf("render failed: %s", err)
+	}
+	if !strings.Contains(string(page.HTML), "/ glue") {
+		t.Errorf("unexpected html %q", page.HTML)
+	}
+	if !strings.Contains(string(page.Preloads), "/assets/App.5678.js") {
+		t.Errorf("preloads missing App chunk: %s", page.Preloads)
+	}
+	if !strings.Contains(string(page.Tags), "/assets/main.1234.js") {
+		t.Errorf("tags missing entry: %s", page.Tags)
+	}
+}
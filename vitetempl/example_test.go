@@ -0,0 +1,48 @@
+package vitetempl_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing/fstest"
+
+	"github.com/a-h/templ"
+	vueglue "github.com/torenware/vite-go"
+	"github.com/torenware/vite-go/vitetempl"
+)
+
+func Example() {
+	// In a real program, FS is an embed.FS or os.DirFS holding
+	// the frontend build.
+	dist := fstest.MapFS{
+		"dist/.vite/manifest.json": {Data: []byte(`{
+  "src/main.ts": {"file": "assets/main-4f1c.js", "src": "src/main.ts", "isEntry": true, "css": ["assets/main-0f2a.css"]}
+}`)},
+		"dist/assets/main-4f1c.js":  {Data: []byte("console.log('hello')\n")},
+		"dist/assets/main-0f2a.css": {Data: []byte("body { margin: 0 }\n")},
+	}
+	glue, err := vueglue.NewVueGlue(&vueglue.ViteConfig{
+		Environment: "production",
+		FS:          dist,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// A page written in templ would use @vitetempl.CSS(glue) and
+	// so on; the nonce would usually be set by middleware.
+	ctx := templ.WithNonce(context.Background(), "r4nd0m")
+	for _, component := range []templ.Component{
+		vitetempl.CSS(glue),
+		vitetempl.Props("app-props", map[string]string{"user": "gopher"}),
+	} {
+		var html strings.Builder
+		if err := component.Render(ctx, &html); err != nil {
+			panic(err)
+		}
+		fmt.Println(strings.TrimSpace(html.String()))
+	}
+	// Output:
+	// <link rel="stylesheet" href="/assets/main-0f2a.css" nonce="r4nd0m">
+	// <script type="application/json" id="app-props" nonce="r4nd0m">{"user":"gopher"}</script>
+}
@@ -0,0 +1,8 @@
+module github.com/torenware/vite-go/vitetempl
+
+go 1.23.0
+
+require (
+	github.com/a-h/templ v0.3.977
+	github.com/torenware/vite-go v0.0.0-20261019043632-4019836cf308
+)
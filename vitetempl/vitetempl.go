@@ -0,0 +1,88 @@
+// Package vitetempl renders a VueGlue's tags as templ components,
+// for pages written with github.com/a-h/templ:
+//
+//	templ Page(glue *vueglue.VueGlue, props any) {
+//		<html>
+//			<head>
+//				@vitetempl.CSS(glue)
+//				@vitetempl.Preloads(glue)
+//			</head>
+//			<body>
+//				<div id="app"></div>
+//				@vitetempl.Props("app-props", props)
+//				@vitetempl.Preamble(glue)
+//				@vitetempl.Entry(glue)
+//			</body>
+//		</html>
+//	}
+//
+// or just @vitetempl.Tags(glue) where the tags go together. The
+// components render from the glue's current manifest snapshot,
+// so they follow a Reload, and script tags get the CSP nonce set
+// on the context with templ.WithNonce.
+package vitetempl
+
+import (
+	"context"
+	"html/template"
+	"io"
+
+	"github.com/a-h/templ"
+	vueglue "github.com/torenware/vite-go"
+)
+
+// Tags renders everything RenderTags does: in development the
+// platform's preamble and the entry script, and in production
+// the entry script with its preloads and CSS.
+func Tags(glue *vueglue.VueGlue) templ.Component {
+	return render(glue.RenderTagsWithNonce)
+}
+
+// Entry renders the entry's script tags alone; see
+// VueGlue.RenderScripts.
+func Entry(glue *vueglue.VueGlue) templ.Component {
+	return render(glue.RenderScripts)
+}
+
+// Preamble renders the dev preamble of the glue's platform, such
+// as the React Fast Refresh runtime, which must come before
+// Entry. It renders nothing in production.
+func Preamble(glue *vueglue.VueGlue) templ.Component {
+	return render(glue.RenderPreamble)
+}
+
+// Preloads renders a modulepreload link for each chunk the entry
+// imports; see VueGlue.RenderPreloads. In development, Vite loads
+// imports itself, and this renders nothing.
+func Preloads(glue *vueglue.VueGlue) templ.Component {
+	return render(glue.RenderPreloads)
+}
+
+// CSS renders a stylesheet link for each CSS file of the entry;
+// see VueGlue.RenderCSS. In development, Vite injects the CSS from
+// JS, and this renders nothing.
+func CSS(glue *vueglue.VueGlue) templ.Component {
+	return render(glue.RenderCSS)
+}
+
+// Props renders data as JSON in a script tag with the given id,
+// for the app to read when it mounts or hydrates; see
+// VueGlue.RenderProps.
+func Props(id string, data interface{}) templ.Component {
+	return render(func(nonce string) (template.HTML, error) {
+		return vueglue.PropsTag(id, data, nonce)
+	})
+}
+
+// render makes a component of a function rendering tags with
+// the context's nonce.
+func render(tags func(nonce string) (template.HTML, error)) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		html, err := tags(templ.GetNonce(ctx))
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, string(html))
+		return err
+	})
+}
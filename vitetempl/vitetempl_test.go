@@ -0,0 +1,103 @@
+package vitetempl
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"github.com/torenware/vite-go/vitetest"
+)
+
+func renderString(t *testing.T, ctx context.Context, c templ.Component) template.HTML {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := c.Render(ctx, &buf); err != nil {
+		t.Fatalf("component did not render: %s", err)
+	}
+	return template.HTML(buf.String())
+}
+
+func TestProduction(t *testing.T) {
+	glue := vitetest.NewManifest().
+		Entry("src/main.ts", "assets/main-4f1c.js").Imports("_vendor-b43f.js").CSS("assets/main-0f2a.css").
+		Chunk("_vendor-b43f.js", "assets/vendor-b43f.js").
+		Glue(t)
+	ctx := templ.WithNonce(context.Background(), "n0nce")
+
+	tags := renderString(t, ctx, Tags(glue))
+	vitetest.AssertModuleScript(t, tags, "/assets/main-4f1c.js")
+	vitetest.AssertModulePreload(t, tags, "/assets/vendor-b43f.js")
+	vitetest.AssertStylesheet(t, tags, "/assets/main-0f2a.css")
+	vitetest.AssertNonce(t, tags, "n0nce")
+
+	entry := renderString(t, ctx, Entry(glue))
+	vitetest.AssertModuleScript(t, entry, "/assets/main-4f1c.js")
+	vitetest.AssertNonce(t, entry, "n0nce")
+	vitetest.AssertNoTag(t, entry, "/assets/vendor-b43f.js")
+	vitetest.AssertNoTag(t, entry, "/assets/main-0f2a.css")
+
+	preloads := renderString(t, ctx, Preloads(glue))
+	vitetest.AssertModulePreload(t, preloads, "/assets/vendor-b43f.js")
+	vitetest.AssertNoTag(t, preloads, "/assets/main-4f1c.js")
+
+	css := renderString(t, ctx, CSS(glue))
+	vitetest.AssertStylesheet(t, css, "/assets/main-0f2a.css")
+	vitetest.AssertNoTag(t, css, "/assets/main-4f1c.js")
+
+	if preamble := renderString(t, ctx, Preamble(glue)); preamble != "" {
+		t.Errorf("production preamble: %s", preamble)
+	}
+}
+
+func TestDevelopment(t *testing.T) {
+	ds := vitetest.NewDevServer(t)
+	glue := ds.Glue(t, vitetest.ProjectFS(t, "react"), "src/main.tsx")
+	ctx := templ.WithNonce(context.Background(), "n0nce")
+
+	preamble := renderString(t, ctx, Preamble(glue))
+	if !strings.Contains(string(preamble), "/@react-refresh") {
+		t.Errorf("no React preamble:\n%s", preamble)
+	}
+	vitetest.AssertNonce(t, preamble, "n0nce")
+
+	entry := renderString(t, ctx, Entry(glue))
+	vitetest.AssertModuleScript(t, entry, ds.URL+"/src/main.tsx")
+	if strings.Contains(string(entry), "@react-refresh") {
+		t.Errorf("entry has the preamble:\n%s", entry)
+	}
+
+	tags := renderString(t, ctx, Tags(glue))
+	vitetest.AssertModuleScript(t, tags, ds.URL+"/src/main.tsx")
+	if !strings.Contains(string(tags), "/@react-refresh") {
+		t.Errorf("tags have no preamble:\n%s", tags)
+	}
+
+	for name, c := range map[string]templ.Component{"Preloads": Preloads(glue), "CSS": CSS(glue)} {
+		if out := renderString(t, ctx, c); out != "" {
+			t.Errorf("%s rendered in development: %s", name, out)
+		}
+	}
+}
+
+func TestProps(t *testing.T) {
+	props := map[string]string{"user": "</script><b>"}
+
+	out := renderString(t, templ.WithNonce(context.Background(), "n0nce"), Props("app-props", props))
+	want := `<script type="application/json" id="app-props" nonce="n0nce">{"user":"\u003c/script\u003e\u003cb\u003e"}</script>`
+	if string(out) != want {
+		t.Errorf("got %s\nwant %s", out, want)
+	}
+
+	out = renderString(t, context.Background(), Props("app-props", props))
+	if strings.Contains(string(out), "nonce") {
+		t.Errorf("props have a nonce without one in the context: %s", out)
+	}
+
+	var buf bytes.Buffer
+	if err := Props("bad", func() {}).Render(context.Background(), &buf); err == nil {
+		t.Errorf("expected an error for props that cannot be serialized")
+	}
+}
@@ -0,0 +1,109 @@
+package vitetest
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	tagRE  = regexp.MustCompile(`(?is)<(script|link)\b([^>]*)>`)
+	attrRE = regexp.MustCompile(`([\w-]+)(?:\s*=\s*"([^"]*)")?`)
+)
+
+// Tag is a script or link tag from rendered tags.
+type Tag struct {
+	Name  string
+	Attrs map[string]string
+}
+
+// Has reports whether the tag has the attribute (with any
+// value, for boolean attributes like nomodule).
+func (t Tag) Has(attr string) bool {
+	_, ok := t.Attrs[attr]
+	return ok
+}
+
+// ParseTags finds the script and link tags in rendered HTML.
+func ParseTags(rendered template.HTML) []Tag {
+	var tags []Tag
+	for _, match := range tagRE.FindAllStringSubmatch(string(rendered), -1) {
+		tag := Tag{Name: strings.ToLower(match[1]), Attrs: map[string]string{}}
+		for _, attr := range attrRE.FindAllStringSubmatch(match[2], -1) {
+			tag.Attrs[strings.ToLower(attr[1])] = html.UnescapeString(attr[2])
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// find returns the tags matching name and the attribute values.
+func find(rendered template.HTML, name string, attrs map[string]string) []Tag {
+	var found []Tag
+outer:
+	for _, tag := range ParseTags(rendered) {
+		if tag.Name != name {
+			continue
+		}
+		for attr, value := range attrs {
+			if got, ok := tag.Attrs[attr]; !ok || got != value {
+				continue outer
+			}
+		}
+		found = append(found, tag)
+	}
+	return found
+}
+
+func assertOne(tb testing.TB, rendered template.HTML, what, name string, attrs map[string]string) {
+	tb.Helper()
+	switch found := find(rendered, name, attrs); len(found) {
+	case 1:
+	case 0:
+		tb.Errorf("no %s in:\n%s", what, rendered)
+	default:
+		tb.Errorf("%d of %s in:\n%s", len(found), what, rendered)
+	}
+}
+
+// AssertModuleScript checks that the tags load src, once, as a
+// module script.
+func AssertModuleScript(tb testing.TB, rendered template.HTML, src string) {
+	tb.Helper()
+	assertOne(tb, rendered, "module script "+src, "script", map[string]string{"type": "module", "src": src})
+}
+
+// AssertStylesheet checks that the tags link href, once, as a
+// stylesheet.
+func AssertStylesheet(tb testing.TB, rendered template.HTML, href string) {
+	tb.Helper()
+	assertOne(tb, rendered, "stylesheet "+href, "link", map[string]string{"rel": "stylesheet", "href": href})
+}
+
+// AssertModulePreload checks that the tags preload href, once.
+func AssertModulePreload(tb testing.TB, rendered template.HTML, href string) {
+	tb.Helper()
+	assertOne(tb, rendered, "modulepreload "+href, "link", map[string]string{"rel": "modulepreload", "href": href})
+}
+
+// AssertNoTag checks that no tag references url, as src or href.
+func AssertNoTag(tb testing.TB, rendered template.HTML, url string) {
+	tb.Helper()
+	for _, tag := range ParseTags(rendered) {
+		if tag.Attrs["src"] == url || tag.Attrs["href"] == url {
+			tb.Errorf("unexpected <%s> for %s in:\n%s", tag.Name, url, rendered)
+		}
+	}
+}
+
+// AssertNonce checks that every script tag carries the nonce.
+func AssertNonce(tb testing.TB, rendered template.HTML, nonce string) {
+	tb.Helper()
+	for _, tag := range ParseTags(rendered) {
+		if tag.Name == "script" && tag.Attrs["nonce"] != nonce {
+			tb.Errorf("script tag %v lacks nonce %q in:\n%s", tag.Attrs, nonce, rendered)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package vitetest
+
+import (
+	"io/fs"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+// clientStub stands in for @vite/client and the other modules
+// Vite serves itself.
+const clientStub = "// vitetest: stand-in for a Vite dev server module\nexport {};\n"
+
+// Request is a request the fake dev server received.
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+}
+
+// DevServer is a fake Vite dev server. It serves @vite/client,
+// @react-refresh and the modules added to it, answers 404 for
+// anything else, and records every request.
+type DevServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	modules  map[string]string
+	requests []Request
+}
+
+// NewDevServer starts a fake dev server, which is closed when
+// the test ends.
+func NewDevServer(tb testing.TB) *DevServer {
+	tb.Helper()
+	ds := &DevServer{
+		modules: map[string]string{
+			"/@vite/client":   clientStub,
+			"/@react-refresh": clientStub,
+		},
+	}
+	ds.Server = httptest.NewServer(http.HandlerFunc(ds.serve))
+	tb.Cleanup(ds.Close)
+	return ds
+}
+
+// Module adds a file for the server to serve, at a path like
+// src/main.ts.
+func (ds *DevServer) Module(path, source string) *DevServer {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.modules["/"+strings.TrimPrefix(path, "/")] = source
+	return ds
+}
+
+func (ds *DevServer) serve(w http.ResponseWriter, r *http.Request) {
+	ds.mu.Lock()
+	ds.requests = append(ds.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+	})
+	source, ok := ds.modules[r.URL.Path]
+	ds.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	contentType := "text/javascript"
+	if strings.HasSuffix(r.URL.Path, ".css") && strings.Contains(r.Header.Get("Accept"), "text/css") {
+		contentType = "text/css"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	_, _ = w.Write([]byte(source))
+}
+
+// Requests returns the requests received so far.
+func (ds *DevServer) Requests() []Request {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	return append([]Request(nil), ds.requests...)
+}
+
+// Requested reports whether path was requested.
+func (ds *DevServer) Requested(path string) bool {
+	for _, req := range ds.Requests() {
+		if req.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// Config returns a development config for this server. fsys is
+// the JS project; ProjectFS makes a minimal one.
+func (ds *DevServer) Config(fsys fs.FS) *vueglue.ViteConfig {
+	host, port, _ := net.SplitHostPort(ds.Listener.Addr().String())
+	return &vueglue.ViteConfig{
+		Environment:     "development",
+		FS:              fsys,
+		DevServerDomain: host,
+		DevServerPort:   port,
+	}
+}
+
+// Glue returns a development glue for this server, for a
+// project with the given entry point.
+func (ds *DevServer) Glue(tb testing.TB, fsys fs.FS, entryPoint string) *vueglue.VueGlue {
+	tb.Helper()
+	config := ds.Config(fsys)
+	config.EntryPoint = entryPoint
+	glue, err := vueglue.NewVueGlue(config)
+	if err != nil {
+		tb.Fatalf("vitetest: could not create glue: %s", err)
+	}
+	return glue
+}
@@ -0,0 +1,172 @@
+package vitetest
+
+import (
+	"encoding/json"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+// ManifestBuilder builds a manifest, one chunk at a time:
+//
+//	b := vitetest.NewManifest().
+//		Entry("src/main.ts", "assets/main-4f1c.js").Imports("_vendor-b43f.js").CSS("assets/main-0f2a.css").
+//		Chunk("_vendor-b43f.js", "assets/vendor-b43f.js")
+//	glue := b.Glue(t)
+//
+// The Imports, DynamicImports, CSS, Assets and DynamicEntry
+// methods apply to the chunk added last, and panic if no chunk
+// has been added yet.
+type ManifestBuilder struct {
+	manifest     vueglue.Manifest
+	current      *vueglue.Chunk
+	manifestPath string
+}
+
+// NewManifest starts an empty manifest.
+func NewManifest() *ManifestBuilder {
+	return &ManifestBuilder{
+		manifest:     vueglue.Manifest{},
+		manifestPath: "dist/.vite/manifest.json",
+	}
+}
+
+func (b *ManifestBuilder) add(chunk *vueglue.Chunk) *ManifestBuilder {
+	b.manifest[chunk.Key] = chunk
+	b.current = chunk
+	return b
+}
+
+// last returns the chunk added last, for method to change. It
+// panics if there is none, since the builder was misused.
+func (b *ManifestBuilder) last(method string) *vueglue.Chunk {
+	if b.current == nil {
+		panic("vitetest: ManifestBuilder." + method + " called before Entry, Chunk or Asset")
+	}
+	return b.current
+}
+
+// Entry adds an entry point built from src into file.
+func (b *ManifestBuilder) Entry(src, file string) *ManifestBuilder {
+	return b.add(&vueglue.Chunk{Key: src, Src: src, File: file, IsEntry: true})
+}
+
+// Chunk adds a shared chunk, such as _vendor-b43f.js.
+func (b *ManifestBuilder) Chunk(key, file string) *ManifestBuilder {
+	return b.add(&vueglue.Chunk{Key: key, File: file})
+}
+
+// Asset adds an asset imported by a module, such as an image.
+func (b *ManifestBuilder) Asset(src, file string) *ManifestBuilder {
+	return b.add(&vueglue.Chunk{Key: src, Src: src, File: file})
+}
+
+// Imports adds static imports, by manifest key.
+func (b *ManifestBuilder) Imports(keys ...string) *ManifestBuilder {
+	chunk := b.last("Imports")
+	chunk.Imports = append(chunk.Imports, keys...)
+	return b
+}
+
+// DynamicImports adds dynamic imports, by manifest key.
+func (b *ManifestBuilder) DynamicImports(keys ...string) *ManifestBuilder {
+	chunk := b.last("DynamicImports")
+	chunk.DynamicImports = append(chunk.DynamicImports, keys...)
+	return b
+}
+
+// CSS adds CSS files.
+func (b *ManifestBuilder) CSS(files ...string) *ManifestBuilder {
+	chunk := b.last("CSS")
+	chunk.CSS = append(chunk.CSS, files...)
+	return b
+}
+
+// Assets adds asset files.
+func (b *ManifestBuilder) Assets(files ...string) *ManifestBuilder {
+	chunk := b.last("Assets")
+	chunk.Assets = append(chunk.Assets, files...)
+	return b
+}
+
+// DynamicEntry marks the chunk as dynamically imported. As in
+// Vite's manifests, such a chunk is not an entry of the build.
+func (b *ManifestBuilder) DynamicEntry() *ManifestBuilder {
+	chunk := b.last("DynamicEntry")
+	chunk.IsDynamicEntry = true
+	chunk.IsEntry = false
+	return b
+}
+
+// ManifestPath sets where DistFS puts the manifest. The default
+// is dist/.vite/manifest.json, as Vite 5 and later write it; use
+// dist/manifest.json for earlier versions.
+func (b *ManifestBuilder) ManifestPath(name string) *ManifestBuilder {
+	b.manifestPath = name
+	return b
+}
+
+// Manifest returns the manifest built so far.
+func (b *ManifestBuilder) Manifest() vueglue.Manifest {
+	manifest := vueglue.Manifest{}
+	for key, chunk := range b.manifest {
+		clone := *chunk
+		manifest[key] = &clone
+	}
+	return manifest
+}
+
+// JSON returns the manifest as Vite would write it.
+func (b *ManifestBuilder) JSON() []byte {
+	contents, err := json.MarshalIndent(b.manifest, "", "  ")
+	if err != nil {
+		// a map of plain structs always marshals
+		panic(err)
+	}
+	return contents
+}
+
+// DistFS returns a JS project holding the build: the manifest,
+// and a stub for every file it references.
+func (b *ManifestBuilder) DistFS() fstest.MapFS {
+	dir := path.Dir(b.manifestPath)
+	if path.Base(dir) == ".vite" {
+		dir = path.Dir(dir)
+	}
+	fsys := fstest.MapFS{b.manifestPath: {Data: b.JSON()}}
+	stub := func(file string) {
+		contents := "/* vitetest */\n"
+		fsys[path.Join(dir, file)] = &fstest.MapFile{Data: []byte(contents)}
+	}
+	for _, chunk := range b.manifest {
+		stub(chunk.File)
+		for _, file := range chunk.CSS {
+			stub(file)
+		}
+		for _, file := range chunk.Assets {
+			stub(file)
+		}
+	}
+	return fsys
+}
+
+// Config returns a production config for the build in DistFS.
+func (b *ManifestBuilder) Config() *vueglue.ViteConfig {
+	return &vueglue.ViteConfig{
+		Environment:  "production",
+		FS:           b.DistFS(),
+		ManifestPath: b.manifestPath,
+	}
+}
+
+// Glue returns a production glue for the build in DistFS.
+func (b *ManifestBuilder) Glue(tb testing.TB) *vueglue.VueGlue {
+	tb.Helper()
+	glue, err := vueglue.NewVueGlue(b.Config())
+	if err != nil {
+		tb.Fatalf("vitetest: could not create glue: %s", err)
+	}
+	return glue
+}
@@ -0,0 +1,37 @@
+// Package vitetest helps test Go programs that use vite-go,
+// without Node or a real Vite build:
+//
+//   - DevServer is a fake Vite dev server, for testing handlers
+//     in development mode.
+//   - ManifestBuilder builds manifests, and dist directories to
+//     go with them, for testing in production mode.
+//   - The Assert functions check the tags a glue renders.
+package vitetest
+
+import (
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+)
+
+// ProjectFS returns a JS project with just a package.json, which
+// is all a development glue needs to start. platform is the npm
+// package of the framework (vue, react, ...), or "" for a vanilla
+// project.
+func ProjectFS(tb testing.TB, platform string) fstest.MapFS {
+	tb.Helper()
+	pkg := map[string]interface{}{
+		"name":            "vitetest",
+		"private":         true,
+		"type":            "module",
+		"devDependencies": map[string]string{"vite": "^5.0.0"},
+	}
+	if platform != "" {
+		pkg["dependencies"] = map[string]string{platform: "^1.0.0"}
+	}
+	contents, err := json.Marshal(pkg)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return fstest.MapFS{"package.json": {Data: contents}}
+}
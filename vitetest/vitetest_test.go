@@ -0,0 +1,142 @@
+package vitetest
+
+import (
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	vueglue "github.com/torenware/vite-go"
+)
+
+func TestDevServer(t *testing.T) {
+	ds := NewDevServer(t).Module("src/main.tsx", `console.log("hi")`)
+	glue := ds.Glue(t, ProjectFS(t, "react"), "src/main.tsx")
+
+	tags, err := glue.RenderTagsWithNonce("n0nce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertModuleScript(t, tags, ds.URL+"/src/main.tsx")
+	AssertNonce(t, tags, "n0nce")
+	if !strings.Contains(string(tags), "/@react-refresh") {
+		t.Errorf("no React preamble:\n%s", tags)
+	}
+
+	for _, path := range []string{"/@vite/client", "/src/main.tsx"} {
+		resp, err := http.Get(ds.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || len(body) == 0 {
+			t.Errorf("%s: %s", path, resp.Status)
+		}
+	}
+	resp, err := http.Get(ds.URL + "/src/missing.ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("missing module: %s", resp.Status)
+	}
+
+	if !ds.Requested("/src/main.tsx") || !ds.Requested("/src/missing.ts") || ds.Requested("/src/other.ts") {
+		t.Errorf("requests not recorded: %+v", ds.Requests())
+	}
+}
+
+func TestManifestBuilder(t *testing.T) {
+	b := NewManifest().
+		Entry("src/main.ts", "assets/main-4f1c.js").Imports("_vendor-b43f.js").CSS("assets/main-0f2a.css").Assets("assets/logo-03d6.png").
+		Entry("src/views/About.vue", "assets/About-7f6e.js").DynamicEntry().
+		Chunk("_vendor-b43f.js", "assets/vendor-b43f.js")
+	glue := b.Glue(t)
+
+	if err := glue.Verify(); err != nil {
+		t.Errorf("DistFS does not match the manifest: %s", err)
+	}
+	if glue.ManifestPath() != "dist/.vite/manifest.json" {
+		t.Errorf("manifest at %s", glue.ManifestPath())
+	}
+
+	tags, err := glue.RenderTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertModuleScript(t, tags, "/assets/main-4f1c.js")
+	AssertModulePreload(t, tags, "/assets/vendor-b43f.js")
+	AssertStylesheet(t, tags, "/assets/main-0f2a.css")
+	AssertNoTag(t, tags, "/assets/About-7f6e.js")
+
+	// the JSON is what Vite would write
+	parsed, err := vueglue.ParseManifest(b.JSON())
+	if err != nil {
+		t.Fatalf("JSON did not parse: %s", err)
+	}
+	if !parsed.Snapshot().Manifest["src/views/About.vue"].IsDynamicEntry {
+		t.Error("dynamic entry lost")
+	}
+
+	legacy := NewManifest().Entry("src/main.js", "assets/main.1234.js").ManifestPath("dist/manifest.json")
+	if _, ok := legacy.DistFS()["dist/assets/main.1234.js"]; !ok {
+		t.Errorf("stub not next to the manifest: %v", legacy.DistFS())
+	}
+	if legacy.Glue(t).ManifestPath() != "dist/manifest.json" {
+		t.Error("older layout not used")
+	}
+}
+
+// recorder catches assertion failures.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, format)
+}
+
+func TestManifestBuilderMisuse(t *testing.T) {
+	for name, misuse := range map[string]func(*ManifestBuilder){
+		"Imports":        func(b *ManifestBuilder) { b.Imports("_vendor.js") },
+		"DynamicImports": func(b *ManifestBuilder) { b.DynamicImports("src/About.vue") },
+		"CSS":            func(b *ManifestBuilder) { b.CSS("assets/main.css") },
+		"Assets":         func(b *ManifestBuilder) { b.Assets("assets/logo.png") },
+		"DynamicEntry":   func(b *ManifestBuilder) { b.DynamicEntry() },
+	} {
+		func() {
+			defer func() {
+				msg, _ := recover().(string)
+				if !strings.Contains(msg, "ManifestBuilder."+name+" called before Entry") {
+					t.Errorf("%s: unexpected panic %q", name, msg)
+				}
+			}()
+			misuse(NewManifest())
+		}()
+	}
+}
+
+func TestAssertions(t *testing.T) {
+	tags := ParseTags(`<script type="module" crossorigin src="/a.js" nonce="x"></script><link rel="modulepreload" href="/b.js"><script nomodule src="/c.js"></script>`)
+	if len(tags) != 3 || !tags[2].Has("nomodule") || tags[0].Attrs["nonce"] != "x" {
+		t.Errorf("tags not parsed: %+v", tags)
+	}
+
+	rendered := template.HTML(`<script type="module" src="/a.js"></script><script type="module" src="/a.js"></script>`)
+	r := &recorder{TB: t}
+	AssertModuleScript(r, rendered, "/a.js")
+	AssertModuleScript(r, rendered, "/b.js")
+	AssertNonce(r, rendered, "x")
+	AssertNoTag(r, rendered, "/a.js")
+	// the duplicate, the missing script, and two each for
+	// the nonces and the unwanted tags
+	if len(r.failures) != 6 {
+		t.Errorf("expected 6 failures, got %d", len(r.failures))
+	}
+}
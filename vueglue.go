@@ -3,7 +3,10 @@ package vueglue
 import (
 	"embed"
 	"errors"
+	"html/template"
 	"io/fs"
+	"log/slog"
+	"sync/atomic"
 )
 
 // constants
@@ -66,60 +69,257 @@ type ViteConfig struct {
 	// Entry point: as configured in vite.config.js. Typically
 	// src/main.js or src/main.ts.
 	EntryPoint string
+
+	// Logger receives the library's diagnostic output.
+	// Default is slog.Default().
+	Logger *slog.Logger `json:"-"`
+
+	// Debug logs extra detail about the files being served.
+	Debug bool
+
+	// SSR renders pages on the server for VueGlue.Render.
+	// Optional; see NodeRenderer.
+	SSR SSRRenderer `json:"-"`
+
+	// ManifestPath is where the build manifest is, relative to
+	// the JS project. By default it is looked for in AssetsPath,
+	// as .vite/manifest.json (Vite 5 and later) and then as
+	// manifest.json (earlier versions).
+	ManifestPath string
+
+	// Manifest is the build's manifest, already parsed, as
+	// generated by cmd/vitegen. If set, the manifest is not read
+	// from the FS in production, and Reload is not available.
+	Manifest Manifest `json:"-"`
+
+	// SSRManifestPath is where `vite build --ssrManifest` wrote
+	// its manifest, relative to the JS project. It is used to
+	// preload the modules a server render needed.
+	// Default is ssr-manifest.json in the AssetsPath, or in its
+	// .vite directory.
+	SSRManifestPath string
+
+	// SkipVerify turns off the checks made in production: that
+	// the files the manifest references are in the FS (see
+	// Verify), and, if the JS sources are in the FS as well,
+	// that the build matches them (see CheckBuildStamp).
+	SkipVerify bool
 }
 
 // type VueGlue summarizes a manifest file, and points to the assets.
+//
+// A VueGlue is immutable once NewVueGlue returns it, and is safe
+// to share across goroutines. Its state is read through accessor
+// methods. The manifest-derived part of the state lives in a
+// Snapshot, which Reload replaces atomically.
 type VueGlue struct {
 
 	// Environment. This controls whether the library will
 	// configure the host for hot updating, or whether it
 	// needs to configure loading of a dist/ directory.
-	Environment string
-
-	// Entry point for JS
-	MainModule string
+	environment string
 
 	// BaseURL is the base URL for the dev server.
 	// Default is http://localhost:5173
-	BaseURL string
-
-	// JS Dependencies / Vendor libs
-	Imports []string
-
-	// Bundled CSS
-	CSSModule []string
+	baseURL string
 
 	// Target JS Platform
-	Platform string
+	platform string
 
 	// A file system or embed that points to the Vue/Vite dist
 	// directory (production) or the javascript src directory
 	// (development)
-	DistFS fs.FS
-
-	// DevServer is the URI of the Vite development server
-	DevServer string
+	distFS fs.FS
 
 	// JSProjectPath is the location of the JS project.
-	JSProjectPath string
+	jsProjectPath string
 
 	// AssetPath is the relative path from the JSDirectory.
-	AssetPath string
+	assetPath string
 
 	// Debug mode
-	Debug bool
+	debug bool
+
+	// logger receives diagnostic and request log output.
+	logger *slog.Logger
+
+	// config is the resolved configuration the glue was built
+	// from; handed out by value from Config().
+	config ViteConfig
+
+	// manifestFile is where the manifest was found in DistFS,
+	// and ssrManifestFile where the SSR manifest would be.
+	manifestFile    string
+	ssrManifestFile string
+
+	// ssr renders pages server side.
+	ssr SSRRenderer
+
+	// state is the current manifest snapshot.
+	state atomic.Pointer[Snapshot]
+}
+
+// Snapshot is the manifest-derived state of a glue at one
+// point in time. Snapshots are never modified; a Reload
+// builds a new one.
+type Snapshot struct {
+
+	// Entry point for JS
+	MainModule string
+
+	// JS Dependencies / Vendor libs
+	Imports []string
+
+	// Bundled CSS
+	CSSModule []string
+
+	// LegacyModule and LegacyPolyfills are the legacy build of
+	// the entry point and its polyfills, and ModernPolyfills the
+	// polyfills for modern browsers, when the app is built with
+	// @vitejs/plugin-legacy.
+	LegacyModule    string
+	LegacyPolyfills string
+	ModernPolyfills string
+
+	// Manifest is the full chunk graph (production only).
+	// It is shared between snapshot copies, and must not be
+	// modified.
+	Manifest Manifest
+
+	// tags is the output of RenderTags for this snapshot.
+	tags template.HTML
+
+	// ssrManifest is the SSR manifest, if the build has one.
+	ssrManifest SSRManifest
+
+	// raw is the manifest the snapshot was parsed from.
+	raw []byte
+}
+
+// Environment is either "development" or "production".
+func (vg *VueGlue) Environment() string {
+	return vg.environment
+}
+
+// BaseURL is the base URL of the dev server, such as
+// http://localhost:5173. Empty in production.
+func (vg *VueGlue) BaseURL() string {
+	return vg.baseURL
+}
+
+// DevServer is the URI of the Vite development server.
+func (vg *VueGlue) DevServer() string {
+	return vg.baseURL
+}
+
+// Platform is the target JS platform (vue, react, ...).
+func (vg *VueGlue) Platform() string {
+	return vg.platform
+}
+
+// DistFS is the file system pointing at the dist directory
+// (production) or the JS project (development).
+func (vg *VueGlue) DistFS() fs.FS {
+	return vg.distFS
+}
+
+// JSProjectPath is the location of the JS project.
+func (vg *VueGlue) JSProjectPath() string {
+	return vg.jsProjectPath
+}
+
+// AssetPath is the path of the dist directory relative to
+// the JS project.
+func (vg *VueGlue) AssetPath() string {
+	return vg.assetPath
+}
+
+// Config returns a copy of the resolved configuration,
+// with all defaults filled in.
+func (vg *VueGlue) Config() ViteConfig {
+	config := vg.config
+	if config.DevDefaults != nil {
+		defaults := *config.DevDefaults
+		config.DevDefaults = &defaults
+	}
+	return config
+}
+
+// Snapshot returns the current manifest state. The slices in
+// the returned value are copies, and may be modified freely.
+func (vg *VueGlue) Snapshot() Snapshot {
+	snap := *vg.state.Load()
+	snap.Imports = append([]string(nil), snap.Imports...)
+	snap.CSSModule = append([]string(nil), snap.CSSModule...)
+	snap.raw = nil
+	return snap
+}
+
+// MainModule is the entry point of the JS app.
+func (vg *VueGlue) MainModule() string {
+	return vg.state.Load().MainModule
+}
+
+// Imports are the JS dependencies (vendor libraries)
+// of the entry point.
+func (vg *VueGlue) Imports() []string {
+	return append([]string(nil), vg.state.Load().Imports...)
+}
+
+// CSSModule is the bundled CSS of the entry point.
+func (vg *VueGlue) CSSModule() []string {
+	return append([]string(nil), vg.state.Load().CSSModule...)
+}
+
+// setSnapshot renders the tags for snap, and makes it the
+// glue's current state.
+func (vg *VueGlue) setSnapshot(snap *Snapshot) error {
+	tags, err := vg.renderTags(snap, "")
+	if err != nil {
+		return err
+	}
+	snap.tags = tags
+	vg.state.Store(snap)
+	return nil
 }
 
-// ParseManifest imports and parses a manifest returning a glue object.
+// ParseManifest imports and parses a manifest returning a
+// production glue object.
 func ParseManifest(contents []byte) (*VueGlue, error) {
-	var testRslt manifestTarget
-	glue, err := testRslt.parseWithoutReflection(contents)
+	snap, err := parseSnapshot(contents, "")
 	if err != nil {
 		return nil, err
 	}
+	glue := &VueGlue{
+		environment: "production",
+	}
+	glue.config.Environment = glue.environment
+	if err := glue.setSnapshot(snap); err != nil {
+		return nil, err
+	}
 	return glue, nil
 }
 
+// parseSnapshot parses a manifest into a Snapshot, using
+// entryPoint as the main module if the manifest has it.
+func parseSnapshot(contents []byte, entryPoint string) (*Snapshot, error) {
+	var testRslt manifestTarget
+	snap, err := testRslt.parseWithoutReflection(contents, entryPoint)
+	if err != nil {
+		return nil, err
+	}
+	snap.raw = contents
+	return snap, nil
+}
+
+// log returns the configured logger, or the slog default.
+func (vg *VueGlue) log() *slog.Logger {
+	if vg.logger != nil {
+		return vg.logger
+	}
+	return slog.Default()
+}
+
 // If we have an embedded FS, modify it to point to the
 // requested assets directory
 func correctEmbedFS(embedded fs.FS, assetsPath string) (fs.FS, error) {
@@ -150,46 +350,83 @@ func correctEmbedFS(embedded fs.FS, assetsPath string) (fs.FS, error) {
 
 // NewVueGlue finds the manifest in the supplied file system
 // and returns a glue object.
+//
+// The config is not modified; defaults are applied to a copy,
+// which is available afterwards from the glue's Config method.
 func NewVueGlue(config *ViteConfig) (*VueGlue, error) {
-	var glue *VueGlue
-	glue = &VueGlue{}
+	resolved := *config
+	glue := &VueGlue{}
+	snap := &Snapshot{}
 
-	correctedFS, err := correctEmbedFS(config.FS, config.JSProjectPath)
+	correctedFS, err := correctEmbedFS(resolved.FS, resolved.JSProjectPath)
 	if err != nil {
 		return nil, err
 	}
 
-	if config.Environment == "production" {
-		err := config.SetProductionDefaults()
+	if resolved.Environment == "production" {
+		err := resolved.SetProductionDefaults()
 		if err != nil {
 			return nil, err
 		}
 
-		// Get the manifest file
-		manifestFile := config.AssetsPath + "/manifest.json"
-		contents, err := fs.ReadFile(correctedFS, manifestFile)
-		if err != nil {
-			return nil, err
+		if resolved.Manifest != nil {
+			// generated by vitegen, so nothing to parse
+			snap, err = snapshotFromManifest(resolved.Manifest, resolved.EntryPoint)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// Get the manifest file
+			manifestFile, contents, err := resolved.findManifest(correctedFS)
+			if err != nil {
+				return nil, err
+			}
+			snap, err = parseSnapshot(contents, resolved.EntryPoint)
+			if err != nil {
+				return nil, err
+			}
+			glue.manifestFile = manifestFile
 		}
-		glue, err = ParseManifest(contents)
-		if err != nil {
-			return nil, err
+
+		if correctedFS != nil {
+			glue.ssrManifestFile = resolved.ssrManifestFile(correctedFS)
+			snap.ssrManifest, err = loadSSRManifest(correctedFS, glue.ssrManifestFile)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 	} else {
-		err := config.SetDevelopmentDefaults()
+		err := resolved.SetDevelopmentDefaults()
 		if err != nil {
 			return nil, err
 		}
-		glue.BaseURL = config.buildDevServerBaseURL()
-		glue.MainModule = config.EntryPoint
+		glue.baseURL = resolved.buildDevServerBaseURL()
+		snap.MainModule = resolved.EntryPoint
 	}
 
-	glue.Environment = config.Environment
-	glue.JSProjectPath = config.JSProjectPath
-	glue.AssetPath = config.AssetsPath
-	glue.Platform = config.Platform
-	glue.DistFS = correctedFS
+	glue.environment = resolved.Environment
+	glue.jsProjectPath = resolved.JSProjectPath
+	glue.assetPath = resolved.AssetsPath
+	glue.platform = resolved.Platform
+	glue.distFS = correctedFS
+	glue.debug = resolved.Debug
+	glue.logger = resolved.Logger
+	glue.ssr = resolved.SSR
+	glue.config = resolved
+
+	if glue.environment == "production" {
+		if err := glue.checkBuild(snap); err != nil {
+			return nil, err
+		}
+		// Hashing the sources is slow, so this is done once, and
+		// not again on Reload.
+		glue.warnIfStale()
+	}
+
+	if err := glue.setSnapshot(snap); err != nil {
+		return nil, err
+	}
 
 	return glue, nil
 }